@@ -3,103 +3,115 @@ package config
 
 import (
 	"time"
-
-	"github.com/spf13/viper"
 )
 
 type AppConfig struct {
 	App struct {
-		Name           string        `mapstructure:"name"`
-		Port           int           `mapstructure:"port"`
-		ReadTimeout    time.Duration `mapstructure:"read_timeout"`
-		WriteTimeout   time.Duration `mapstructure:"write_timeout"`
-		Env            string        `mapstructure:"env"`
-		RateLimit      int           `mapstructure:"rate_limit"`
-		Secret         string        `mapstructure:"secret"`
-		UserServiceURL string        `mapstructure:"user_service_url"`
+		Name           string        `mapstructure:"name" validate:"required"`
+		Port           int           `mapstructure:"port" validate:"min=1,max=65535"`
+		ReadTimeout    time.Duration `mapstructure:"read_timeout" validate:"gte=0"`
+		WriteTimeout   time.Duration `mapstructure:"write_timeout" validate:"gte=0"`
+		GracePeriod    time.Duration `mapstructure:"grace_period" validate:"gte=0"` // Seconds to drain in-flight work on shutdown, 0 uses 30s
+		Env            string        `mapstructure:"env" validate:"required"`
+		RateLimit      int           `mapstructure:"rate_limit" validate:"gte=0"`
+		Secret         string        `mapstructure:"secret" secret:"true"`
+		UserServiceURL string        `mapstructure:"user_service_url" validate:"omitempty,url"`
 	} `mapstructure:"app"`
 
 	Database struct {
-		Type     string `mapstructure:"type"`
-		Host     string `mapstructure:"host"`
-		Port     int    `mapstructure:"port"`
-		Name     string `mapstructure:"name"`
-		User     string `mapstructure:"user"`
-		Password string `mapstructure:"password"`
-		SSLmode  string `mapstructure:"sslmode"`
+		Type     string `mapstructure:"type" validate:"required,oneof=postgres"`
+		Host     string `mapstructure:"host" validate:"required"`
+		Port     int    `mapstructure:"port" validate:"min=1,max=65535"`
+		Name     string `mapstructure:"name" validate:"required"`
+		User     string `mapstructure:"user" validate:"required"`
+		Password string `mapstructure:"password" secret:"true"`
+		SSLmode  string `mapstructure:"sslmode" validate:"omitempty,oneof=disable allow prefer require verify-ca verify-full"`
 	} `mapstructure:"database"`
 
 	Auth struct {
-		JwtSecret   string        `mapstructure:"jwtSecret"`
-		TokenExpiry time.Duration `mapstructure:"tokenExpiry"`
-	}
+		JwtSecret   string        `mapstructure:"jwtSecret" validate:"required" secret:"true"`
+		TokenExpiry time.Duration `mapstructure:"tokenExpiry" validate:"gt=0"`
+	} `mapstructure:"auth"`
 
 	Messaging struct {
-		PubsubBackend string `mapstructure:"pubsub_backend"`
-		PubsubBroker  string `mapstructure:"pubsub_broker"`
+		PubsubBackend string `mapstructure:"pubsub_backend" validate:"required,oneof=nats"`
+		PubsubBroker  string `mapstructure:"pubsub_broker" validate:"required,url"`
 		// Array of stream configurations for multiple streams
-		Streams []NATSStreamConfig `mapstructure:"streams"`
-	}
+		Streams []NATSStreamConfig `mapstructure:"streams" validate:"dive"`
+	} `mapstructure:"messaging"`
 
-	Logger struct {
-		LogLevel string `mapstructure:"log_level"`
-	}
+	Logger LoggerConfig `mapstructure:"logger"`
 
 	Redis struct {
-		Address string `mapstructure:"address"`
+		Address string `mapstructure:"address" validate:"required"`
 	} `mapstructure:"redis"`
 
-	PolicyManager *PolicyManagerConfig `yaml:"policy_manager,omitempty"` // PolicyManager is optional
+	PolicyManager *PolicyManagerConfig `mapstructure:"policy_manager" yaml:"policy_manager,omitempty"` // PolicyManager is optional
 }
 
-// PolicyManagerConfig defines the configuration for the policy manager (optional)
+// PolicyManagerConfig defines the configuration for the policy manager (optional).
+// Service-specific settings belong in custom sections instead, see Section.
 type PolicyManagerConfig struct {
-	// Add any policy manager-specific configuration here
-	// For example, maybe you need to configure an external service
-	// Host      string `yaml:"host"`
-	// Port      int    `yaml:"port"`
+	ReloadInterval time.Duration `mapstructure:"reload_interval" validate:"gte=0"` // Seconds between policy reloads from the database, 0 disables
+}
+
+// LoggerConfig defines the level, format and outputs of the service logs
+type LoggerConfig struct {
+	LogLevel   string             `mapstructure:"log_level" validate:"required,oneof=debug info warn error dpanic panic fatal"`
+	Encoding   string             `mapstructure:"encoding" validate:"omitempty,oneof=json console"`                       // Defaults to json in prd and console otherwise
+	FieldNames string             `mapstructure:"field_names" validate:"omitempty,oneof=default ecs gcp datadog"`         // Field names of the JSON encoder
+	Outputs    []LogOutputConfig  `mapstructure:"outputs" validate:"dive"`                                                // Defaults to stdout
+	Sampling   *LogSamplingConfig `mapstructure:"sampling" yaml:"sampling,omitempty"`                                     // Defaults to 100/100 per second in prd and none otherwise
+	Modules    map[string]string  `mapstructure:"modules" validate:"dive,oneof=debug info warn error dpanic panic fatal"` // Levels of the http, messaging, db and policy loggers, by default the log level
+	Redaction  LogRedactionConfig `mapstructure:"redaction"`
+}
+
+// LogOutputConfig defines a single output of the logs
+type LogOutputConfig struct {
+	Type string `mapstructure:"type" validate:"required,oneof=stdout stderr file syslog"`
+
+	// file
+	Path       string `mapstructure:"path"`                         // Required for file outputs
+	MaxSize    int    `mapstructure:"max_size" validate:"gte=0"`    // Megabytes before the file is rotated, defaults to 100
+	MaxBackups int    `mapstructure:"max_backups" validate:"gte=0"` // Rotated files kept, 0 keeps all
+	MaxAge     int    `mapstructure:"max_age" validate:"gte=0"`     // Days rotated files are kept, 0 keeps them forever
+	Compress   bool   `mapstructure:"compress"`                     // Gzip rotated files
+
+	// syslog
+	Network string `mapstructure:"network" validate:"omitempty,oneof=udp tcp unix unixgram"` // Empty connects to the local syslog daemon
+	Address string `mapstructure:"address"`
+	Tag     string `mapstructure:"tag"` // Defaults to the app name
+}
+
+// LogRedactionConfig defines the values hidden from the logs by the key they are logged under.
+// Passwords, tokens, secrets, authorization headers, cookies and API keys are always redacted and
+// emails masked, unless Disabled.
+type LogRedactionConfig struct {
+	Disabled bool     `mapstructure:"disabled"`
+	Keys     []string `mapstructure:"keys"`    // Case-insensitive regular expressions of additional keys whose values are replaced
+	Partial  []string `mapstructure:"partial"` // Case-insensitive regular expressions of additional keys whose values are partially masked
+}
+
+// LogSamplingConfig limits the logs of each message and level per tick: the first Initial entries
+// are logged, then every Thereafter-th. Zero Initial and Thereafter disable sampling.
+type LogSamplingConfig struct {
+	Initial    int           `mapstructure:"initial" validate:"gte=0"`
+	Thereafter int           `mapstructure:"thereafter" validate:"gte=0"`
+	Tick       time.Duration `mapstructure:"tick" validate:"gte=0"` // Seconds, defaults to 1
 }
 
 // NATSStreamConfig defines the configuration for a single JetStream stream
 type NATSStreamConfig struct {
-	StreamName  string        `mapstructure:"stream_name"`
-	Subjects    []string      `mapstructure:"subjects"`
-	MaxAge      time.Duration `mapstructure:"max_age"`
-	StorageType string        `mapstructure:"storage_type"` // "file" or "memory"
-	Replicas    int           `mapstructure:"replicas"`     // Number of replicas
+	StreamName  string        `mapstructure:"stream_name" validate:"required"`
+	Subjects    []string      `mapstructure:"subjects" validate:"min=1,dive,required"`
+	MaxAge      time.Duration `mapstructure:"max_age" validate:"gte=0"`
+	StorageType string        `mapstructure:"storage_type" validate:"omitempty,oneof=file memory"` // "file" or "memory"
+	Replicas    int           `mapstructure:"replicas" validate:"gte=0,lte=5"`                     // Number of replicas
 }
 
+// LoadConfig loads the configuration from the given file, layered over the built-in
+// defaults and overridden by NEODATA_ environment variables. See LoadConfigWithOptions.
 func LoadConfig(configPath string) (*AppConfig, error) {
-	viper.SetConfigType("yaml")
-	if configPath != "" {
-		viper.SetConfigFile(configPath)
-	} else {
-		viper.SetConfigName("config")
-		viper.AddConfigPath("config") // Fallback path
-	}
-
-	viper.AutomaticEnv() // Automatically overrides config with environment variables
-
-	// Bind environment variables explicitly for sensitive fields
-	//viper.BindEnv("database.password", "DB_PASSWORD")
-	//viper.BindEnv("database.user", "DB_USER")
-	//viper.BindEnv("database.jwtSecret", "JWT_SECRET")
-	//viper.BindEnv("database.secret", "SECRET")
-
-	// Set default values
-	viper.SetDefault("app.port", 8080)
-	viper.SetDefault("app.read_timeout", 10)
-	viper.SetDefault("app.write_timeout", 10)
-	viper.SetDefault("redis.address", "localhost:6379")
-
-	if err := viper.ReadInConfig(); err != nil {
-		return nil, err
-	}
-
-	var config AppConfig
-	if err := viper.Unmarshal(&config); err != nil {
-		return nil, err
-	}
-
-	return &config, nil
+	config, _, err := LoadConfigWithOptions(LoadOptions{ConfigPath: configPath})
+	return config, err
 }
@@ -1,25 +1,275 @@
 // config/viper_config_manager.go
 package config
 
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
 // ConfigManager defines an interface for fetching configuration values
 type ConfigManager interface {
 	GetAppConfig() *AppConfig
+	// Dump outputs the effective configuration with secrets redacted, see Dump
+	Dump(format Format) ([]byte, error)
+}
+
+// ChangeFunc is notified with the previous and the new configuration after a reload
+type ChangeFunc func(old, new *AppConfig)
+
+// Reloadable is implemented by config managers that reload the configuration at runtime
+type Reloadable interface {
+	// OnChange registers a subscriber notified after every successful reload
+	OnChange(fn ChangeFunc)
+	// OnReloadError registers a subscriber notified when a reload fails; the previous configuration stays in use
+	OnReloadError(fn func(error))
+	// Watch starts reloading the configuration when its source changes. Reloaded configurations are
+	// validated like on startup, with the given sections.
+	Watch(sections ...string) error
+	// StopWatching stops the reloads started by Watch and waits for a reload in progress
+	StopWatching() error
+}
+
+// snapshot is a loaded configuration with the layer each value came from
+type snapshot struct {
+	config   *AppConfig
+	sources  Sources
+	settings map[string]any // Every merged setting, including the custom sections
+	file     string         // Base config file used
+	opts     LoadOptions
+	loadedAt time.Time
 }
 
 type ViperConfigManager struct {
-	config *AppConfig
+	opts    LoadOptions
+	current atomic.Pointer[snapshot]
+
+	mu          sync.Mutex // Guards the fields below and serializes reloads
+	sections    []string
+	custom      map[string]func(*snapshot) error // Validates the custom sections read with Section
+	watcher     *fsnotify.Watcher                // Watches the base config file, nil unless watching
+	watching    chan struct{}                    // Closed once the watch loop exits
+	subscribers []ChangeFunc
+	errHandlers []func(error)
 }
 
 // NewConfigManager loads the configuration and returns an instance of ConfigManager
 func NewConfigManager(configPath string) (*ViperConfigManager, error) {
-	config, err := LoadConfig(configPath)
+	return NewConfigManagerWithOptions(LoadOptions{ConfigPath: configPath})
+}
+
+// NewConfigManagerWithOptions loads the layered configuration, see LoadConfigWithOptions
+func NewConfigManagerWithOptions(opts LoadOptions) (*ViperConfigManager, error) {
+	snap, err := load(opts)
 	if err != nil {
 		return nil, err
 	}
-	return &ViperConfigManager{config: config}, nil
+	manager := &ViperConfigManager{opts: opts, custom: map[string]func(*snapshot) error{}}
+	manager.current.Store(snap)
+	return manager, nil
 }
 
-// GetAppConfig implements the ConfigManager interface
+// GetAppConfig implements the ConfigManager interface. It returns the latest snapshot,
+// which is never modified: a reload swaps in a new one.
 func (c *ViperConfigManager) GetAppConfig() *AppConfig {
+	return c.current.Load().config
+}
+
+// Dump implements the ConfigManager interface
+func (c *ViperConfigManager) Dump(format Format) ([]byte, error) {
+	return Dump(c.GetAppConfig(), format)
+}
+
+// LoadedAt returns when the current configuration was loaded, at startup or by the last reload
+func (c *ViperConfigManager) LoadedAt() time.Time {
+	return c.current.Load().loadedAt
+}
+
+// Settings returns the current configuration as a tree keyed like the config files, with secrets redacted
+func (c *ViperConfigManager) Settings() map[string]any {
+	return Settings(c.GetAppConfig())
+}
+
+// Sources reports the layer (default, file, profile, env or flag) each config key was loaded from
+func (c *ViperConfigManager) Sources() Sources {
+	return c.current.Load().sources
+}
+
+func (c *ViperConfigManager) snapshot() *snapshot {
+	return c.current.Load()
+}
+
+// registerSection makes reloads validate a custom section read with Section
+func (c *ViperConfigManager) registerSection(name string, validate func(*snapshot) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.custom[name] = validate
+}
+
+// OnChange implements the Reloadable interface. Subscribers run in registration order on the watcher
+// goroutine, while the reload lock is held: they may call GetAppConfig but not Reload or OnChange.
+func (c *ViperConfigManager) OnChange(fn ChangeFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.subscribers = append(c.subscribers, fn)
+}
+
+// OnReloadError implements the Reloadable interface
+func (c *ViperConfigManager) OnReloadError(fn func(error)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.errHandlers = append(c.errHandlers, fn)
+}
+
+// Watch implements the Reloadable interface. Changes of the base config file or of the profile
+// file of app.env trigger a reload of every layer.
+func (c *ViperConfigManager) Watch(sections ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.watcher != nil {
+		return fmt.Errorf("config manager is already watching")
+	}
+
+	// The directory is watched to pick up atomic saves and replaced Kubernetes ConfigMaps
+	file := filepath.Clean(c.current.Load().file)
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to watch configuration: %w", err)
+	}
+	if err := watcher.Add(filepath.Dir(file)); err != nil {
+		watcher.Close()
+		return fmt.Errorf("failed to watch configuration: %w", err)
+	}
+
+	c.sections = sections
+	c.watcher = watcher
+	c.watching = make(chan struct{})
+	go c.watch(watcher, file, c.watching)
+	return nil
+}
+
+// StopWatching implements the Reloadable interface
+func (c *ViperConfigManager) StopWatching() error {
+	c.mu.Lock()
+	watcher, watching := c.watcher, c.watching
+	c.watcher, c.watching = nil, nil
+	c.mu.Unlock()
+
+	if watcher == nil {
+		return nil
+	}
+	// The loop takes the reload lock, so it is waited for without holding it
+	err := watcher.Close()
+	<-watching
+	if err != nil {
+		return fmt.Errorf("failed to stop watching configuration: %w", err)
+	}
+	return nil
+}
+
+// watch reloads the configuration when file or the profile of the current app.env is written, or
+// when the files they link to change, until the watcher is closed. Both are in the watched directory.
+func (c *ViperConfigManager) watch(watcher *fsnotify.Watcher, file string, watching chan struct{}) {
+	defer close(watching)
+
+	// The profile follows app.env, which a reload may change
+	profile := ProfilePath(file, c.GetAppConfig().App.Env)
+	realFile, _ := filepath.EvalSymlinks(file)
+	realProfile, _ := filepath.EvalSymlinks(profile)
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			name := filepath.Clean(event.Name)
+			currentFile, _ := filepath.EvalSymlinks(file)
+			currentProfile, _ := filepath.EvalSymlinks(profile)
+			changed := (name == file && event.Has(fsnotify.Write|fsnotify.Create)) ||
+				(profile != "" && name == profile && event.Has(fsnotify.Write|fsnotify.Create|fsnotify.Remove|fsnotify.Rename)) ||
+				(currentFile != "" && currentFile != realFile) ||
+				currentProfile != realProfile
+			if !changed {
+				continue
+			}
+			if err := c.Reload(); err != nil {
+				c.reportError(err)
+			}
+			realFile = currentFile
+			profile = ProfilePath(file, c.GetAppConfig().App.Env)
+			realProfile, _ = filepath.EvalSymlinks(profile)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			c.reportError(fmt.Errorf("failed to watch configuration: %w", err))
+		}
+	}
+}
+
+// Reload loads and validates the configuration again, swaps it in and notifies the subscribers.
+// On error the previous configuration stays in use.
+func (c *ViperConfigManager) Reload() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	snap, err := load(c.opts)
+	if err != nil {
+		return fmt.Errorf("failed to reload configuration: %w", err)
+	}
+	if err := Validate(snap.config, c.sections...); err != nil {
+		return fmt.Errorf("failed to reload configuration: %w", err)
+	}
+	for _, validate := range c.custom {
+		if err := validate(snap); err != nil {
+			return fmt.Errorf("failed to reload configuration: %w", err)
+		}
+	}
+
+	old := c.current.Swap(snap)
+	for _, fn := range c.subscribers {
+		fn(old.config, snap.config)
+	}
+	return nil
+}
+
+func (c *ViperConfigManager) reportError(err error) {
+	c.mu.Lock()
+	handlers := make([]func(error), len(c.errHandlers))
+	copy(handlers, c.errHandlers)
+	c.mu.Unlock()
+
+	for _, fn := range handlers {
+		fn(err)
+	}
+}
+
+// StaticConfigManager serves a configuration built in code, e.g. inside tests.
+type StaticConfigManager struct {
+	config    *AppConfig
+	createdAt time.Time
+}
+
+// NewStaticConfigManager wraps an already built AppConfig in a ConfigManager
+func NewStaticConfigManager(cfg *AppConfig) *StaticConfigManager {
+	return &StaticConfigManager{config: cfg, createdAt: time.Now()}
+}
+
+// GetAppConfig implements the ConfigManager interface
+func (c *StaticConfigManager) GetAppConfig() *AppConfig {
 	return c.config
 }
+
+// Dump implements the ConfigManager interface
+func (c *StaticConfigManager) Dump(format Format) ([]byte, error) {
+	return Dump(c.config, format)
+}
+
+// LoadedAt returns when the manager was created, the configuration never changes afterwards
+func (c *StaticConfigManager) LoadedAt() time.Time {
+	return c.createdAt
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, path, logLevel string) {
+	t.Helper()
+	content := "app:\n  name: test\nlogger:\n  log_level: " + logLevel + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+}
+
+func TestViperConfigManagerStopWatching(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, "info")
+	manager, err := NewConfigManager(path)
+	if err != nil {
+		t.Fatalf("NewConfigManager() error = %v", err)
+	}
+
+	changes := make(chan string, 10)
+	manager.OnChange(func(_, new *AppConfig) { changes <- new.Logger.LogLevel })
+	if err := manager.Watch(); err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+
+	writeConfig(t, path, "debug")
+	select {
+	case level := <-changes:
+		if level != "debug" {
+			t.Errorf("reloaded log level = %q, want debug", level)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("configuration not reloaded")
+	}
+
+	if err := manager.StopWatching(); err != nil {
+		t.Fatalf("StopWatching() error = %v", err)
+	}
+	for len(changes) > 0 { // Writes may be reported more than once
+		<-changes
+	}
+	writeConfig(t, path, "warn")
+	select {
+	case level := <-changes:
+		t.Errorf("configuration reloaded to %q after StopWatching", level)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	if err := manager.Watch(); err != nil {
+		t.Errorf("Watch() after StopWatching error = %v", err)
+	}
+	if err := manager.StopWatching(); err != nil {
+		t.Errorf("StopWatching() error = %v", err)
+	}
+}
+
+func TestViperConfigManagerWatchesProfile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte("app:\n  name: test\n  env: dev\nlogger:\n  log_level: info\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	manager, err := NewConfigManager(path)
+	if err != nil {
+		t.Fatalf("NewConfigManager() error = %v", err)
+	}
+	changes := make(chan string, 10)
+	manager.OnChange(func(_, new *AppConfig) { changes <- new.Logger.LogLevel })
+	if err := manager.Watch(); err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	defer manager.StopWatching()
+
+	waitFor := func(want string) {
+		t.Helper()
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case level := <-changes:
+				if level == want {
+					return
+				}
+			case <-timeout:
+				t.Fatalf("configuration not reloaded with log level %q", want)
+			}
+		}
+	}
+
+	profile := ProfilePath(path, "dev")
+	writeConfig(t, profile, "debug")
+	waitFor("debug")
+
+	writeConfig(t, profile, "warn")
+	waitFor("warn")
+
+	if err := os.Remove(profile); err != nil {
+		t.Fatalf("remove profile: %v", err)
+	}
+	waitFor("info")
+}
@@ -0,0 +1,162 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is an output format of Dump.
+type Format string
+
+const (
+	FormatYAML Format = "yaml"
+	FormatJSON Format = "json"
+)
+
+// Dump outputs the configuration as YAML or JSON, keyed like the config files, with secrets redacted.
+func Dump(cfg *AppConfig, format Format) ([]byte, error) {
+	settings := Settings(cfg)
+	switch format {
+	case FormatYAML:
+		return yaml.Marshal(settings)
+	case FormatJSON:
+		return json.MarshalIndent(settings, "", "  ")
+	default:
+		return nil, fmt.Errorf("unsupported config dump format %q", format)
+	}
+}
+
+// Change is a config value that differs between two configurations.
+type Change struct {
+	Key string `json:"key"`
+	Old any    `json:"old"`
+	New any    `json:"new"`
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s: %v -> %v", c.Key, c.Old, c.New)
+}
+
+// Settings returns the configuration as a tree keyed like the config files, e.g.
+// settings["database"]["host"], with secrets redacted.
+func Settings(cfg *AppConfig) map[string]any {
+	return settingsOf(reflect.ValueOf(cfg.Redacted()).Elem())
+}
+
+// Diff returns the values that differ between two configurations, sorted by key, with secrets
+// redacted. A changed secret is reported with both values redacted.
+func Diff(old, new *AppConfig) []Change {
+	oldValues, newValues := map[string]any{}, map[string]any{}
+	flatten(Settings(old), "", oldValues)
+	flatten(Settings(new), "", newValues)
+
+	keys := make(map[string]bool)
+	for key := range oldValues {
+		keys[key] = true
+	}
+	for key := range newValues {
+		keys[key] = true
+	}
+
+	// Secrets are redacted in both trees, so compare them on the real values
+	var changes []Change
+	_ = walkSecrets(reflect.ValueOf(old).Elem(), "", func(key string, field reflect.Value) error {
+		delete(keys, key)
+		if newValue := secretValue(new, key); field.String() != newValue {
+			changes = append(changes, Change{Key: key, Old: redact(field.String()), New: redact(newValue)})
+		}
+		return nil
+	})
+	for key := range keys {
+		if !reflect.DeepEqual(oldValues[key], newValues[key]) {
+			changes = append(changes, Change{Key: key, Old: oldValues[key], New: newValues[key]})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
+
+// settingsOf converts a struct to a settings tree keyed by the mapstructure tags.
+func settingsOf(v reflect.Value) map[string]any {
+	settings := make(map[string]any, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		field, typ := v.Field(i), v.Type().Field(i)
+		if !typ.IsExported() {
+			continue
+		}
+		key, _, _ := strings.Cut(typ.Tag.Get("mapstructure"), ",")
+		if key == "" {
+			key = typ.Name
+		}
+		settings[strings.ToLower(key)] = settingValue(field)
+	}
+	return settings
+}
+
+// settingValue converts a field value to its settings representation.
+func settingValue(v reflect.Value) any {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return nil
+		}
+		return settingValue(v.Elem())
+	case reflect.Struct:
+		if v.Type() == reflect.TypeOf(time.Time{}) {
+			return v.Interface()
+		}
+		return settingsOf(v)
+	case reflect.Slice:
+		if v.IsNil() {
+			return []any{}
+		}
+		items := make([]any, v.Len())
+		for i := range items {
+			items[i] = settingValue(v.Index(i))
+		}
+		return items
+	default:
+		if v.Type() == reflect.TypeOf(time.Duration(0)) {
+			return v.Int() // Durations are configured as plain numbers
+		}
+		return v.Interface()
+	}
+}
+
+// flatten collects the leaves of a settings tree by dotted key.
+func flatten(settings map[string]any, prefix string, values map[string]any) {
+	for key, value := range settings {
+		if nested, ok := value.(map[string]any); ok {
+			flatten(nested, prefix+key+".", values)
+			continue
+		}
+		values[prefix+key] = value
+	}
+}
+
+// secretValue returns the secret field key of cfg.
+func secretValue(cfg *AppConfig, key string) string {
+	var value string
+	_ = walkSecrets(reflect.ValueOf(cfg).Elem(), "", func(k string, field reflect.Value) error {
+		if k == key {
+			value = field.String()
+		}
+		return nil
+	})
+	return value
+}
+
+// redact hides a non-empty secret.
+func redact(value string) string {
+	if value == "" {
+		return ""
+	}
+	return RedactedValue
+}
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestDiff(t *testing.T) {
+	old := &AppConfig{}
+	old.App.Name, old.App.Port = "orders", 8080
+	old.Database.Password = "s3cret"
+	old.Logger.LogLevel = "info"
+
+	new := &AppConfig{}
+	*new = *old
+	new.App.Port = 9090
+	new.Database.Password = "n3w-s3cret"
+	new.Logger.Modules = map[string]string{"db": "debug"}
+
+	want := []Change{
+		{Key: "app.port", Old: 8080, New: 9090},
+		{Key: "database.password", Old: RedactedValue, New: RedactedValue},
+		{Key: "logger.modules", Old: map[string]string(nil), New: map[string]string{"db": "debug"}},
+	}
+	if got := Diff(old, new); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %v, want %v", got, want)
+	}
+	if got := Diff(old, old); len(got) != 0 {
+		t.Errorf("Diff() of equal configs = %v, want none", got)
+	}
+}
+
+func TestDump(t *testing.T) {
+	cfg := &AppConfig{}
+	cfg.App.Name, cfg.App.Port = "orders", 8080
+	cfg.Auth.JwtSecret = "jwt-s3cret"
+
+	for _, format := range []Format{FormatYAML, FormatJSON} {
+		data, err := Dump(cfg, format)
+		if err != nil {
+			t.Fatalf("Dump(%s) error = %v", format, err)
+		}
+		var settings map[string]any
+		if format == FormatYAML {
+			err = yaml.Unmarshal(data, &settings)
+		} else {
+			err = json.Unmarshal(data, &settings)
+		}
+		if err != nil {
+			t.Fatalf("decode %s dump: %v", format, err)
+		}
+
+		app, _ := settings["app"].(map[string]any)
+		auth, _ := settings["auth"].(map[string]any)
+		if app["name"] != "orders" || auth["jwtsecret"] != RedactedValue {
+			t.Errorf("%s dump = %s, want keys of the config files with secrets redacted", format, data)
+		}
+		if strings.Contains(string(data), "jwt-s3cret") {
+			t.Errorf("%s dump reveals a secret", format)
+		}
+	}
+
+	if _, err := Dump(cfg, "toml"); err == nil {
+		t.Error("Dump(toml) error = nil")
+	}
+}
+
+func TestConfigManagersAreIndependent(t *testing.T) {
+	dir := t.TempDir()
+	paths := make([]string, 2)
+	for i, port := range []string{"8081", "8082"} {
+		paths[i] = filepath.Join(dir, "config"+port+".yaml")
+		if err := os.WriteFile(paths[i], []byte("app:\n  name: orders\n  port: "+port+"\n"), 0o644); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+	}
+
+	// Loaded concurrently, each manager keeps the values of its own file
+	managers := make([]*ViperConfigManager, len(paths))
+	var wg sync.WaitGroup
+	for i, path := range paths {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			manager, err := NewConfigManager(path)
+			if err != nil {
+				t.Errorf("NewConfigManager() error = %v", err)
+				return
+			}
+			managers[i] = manager
+		}()
+	}
+	wg.Wait()
+	if t.Failed() {
+		return
+	}
+
+	if managers[0].GetAppConfig().App.Port != 8081 || managers[1].GetAppConfig().App.Port != 8082 {
+		t.Errorf("ports = %d and %d, want 8081 and 8082", managers[0].GetAppConfig().App.Port, managers[1].GetAppConfig().App.Port)
+	}
+	if source := managers[0].Sources()["app.port"]; source != LayerFile {
+		t.Errorf("app.port comes from %q, want %q", source, LayerFile)
+	}
+}
@@ -0,0 +1,295 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// DefaultEnvPrefix is the prefix of the environment variables overriding config keys,
+// e.g. NEODATA_DATABASE_HOST overrides database.host.
+const DefaultEnvPrefix = "NEODATA"
+
+// Layers a config value can come from, from lowest to highest precedence.
+const (
+	LayerDefault = "default" // Built-in defaults
+	LayerFile    = "file"    // Base config file
+	LayerProfile = "profile" // Profile file selected by app.env, e.g. config.prd.yaml
+	LayerEnv     = "env"     // Environment variables
+	LayerFlag    = "flag"    // Command line flags
+)
+
+// defaults holds the built-in value of every AppConfig key. Every key must have a default so that
+// environment variables and flags can override it even when the config files do not mention it.
+var defaults = map[string]any{
+	"app.name":             "",
+	"app.port":             8080,
+	"app.read_timeout":     10,
+	"app.write_timeout":    10,
+	"app.grace_period":     30,
+	"app.env":              "dev",
+	"app.rate_limit":       0,
+	"app.secret":           "",
+	"app.user_service_url": "",
+
+	"database.type":     "postgres",
+	"database.host":     "localhost",
+	"database.port":     5432,
+	"database.name":     "",
+	"database.user":     "",
+	"database.password": "",
+	"database.sslmode":  "disable",
+
+	"auth.jwtsecret":   "",
+	"auth.tokenexpiry": 3600,
+
+	"messaging.pubsub_backend": "nats",
+	"messaging.pubsub_broker":  "nats://localhost:4222",
+	"messaging.streams":        []any{},
+
+	"logger.log_level":   "info",
+	"logger.encoding":    "",
+	"logger.field_names": "",
+	"logger.outputs":     []any{},
+	"logger.modules":     map[string]any{},
+
+	"logger.sampling.initial":    0, // Sampled like zap's production config in prd, see prdDefaults
+	"logger.sampling.thereafter": 0,
+	"logger.sampling.tick":       1,
+
+	"logger.redaction.disabled": false,
+	"logger.redaction.keys":     []any{},
+	"logger.redaction.partial":  []any{},
+
+	"redis.address": "localhost:6379",
+
+	"policy_manager.reload_interval": 0,
+}
+
+// prdDefaults replace the built-in value of keys whose default depends on app.env when it is prd.
+var prdDefaults = map[string]any{
+	"logger.sampling.initial":    100,
+	"logger.sampling.thereafter": 100,
+}
+
+// LoadOptions configures LoadConfigWithOptions.
+type LoadOptions struct {
+	ConfigPath string         // Base config file; empty searches config/config.yaml
+	EnvPrefix  string         // Prefix of the environment variables, defaults to NEODATA
+	Flags      *pflag.FlagSet // Parsed flags named after config keys, e.g. --app.port, see RegisterFlags
+
+	// SecretProviders resolve secret references besides the built-in file:// and env:// ones, e.g. vault://
+	SecretProviders []SecretProvider
+}
+
+// Sources maps every config key to the layer its effective value came from.
+type Sources map[string]string
+
+// Keys returns the keys of the report in sorted order.
+func (s Sources) Keys() []string {
+	keys := make([]string, 0, len(s))
+	for key := range s {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// LoadConfigWithOptions loads the configuration in layers, each overriding the previous one:
+//
+//  1. built-in defaults
+//  2. the base config file
+//  3. the profile file named after app.env next to it, e.g. config.prd.yaml (optional)
+//  4. environment variables, e.g. NEODATA_DATABASE_HOST for database.host
+//  5. command line flags
+//
+// Secret values (database.password, auth.jwtsecret, app.secret) may be references such as
+// file:///run/secrets/db_password or env://DB_PASS, resolved through the SecretProviders.
+//
+// It returns the configuration and a report of the layer each value came from.
+func LoadConfigWithOptions(opts LoadOptions) (*AppConfig, Sources, error) {
+	snap, err := load(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return snap.config, snap.sources, nil
+}
+
+// load loads every layer into a snapshot.
+func load(opts LoadOptions) (*snapshot, error) {
+	if opts.EnvPrefix == "" {
+		opts.EnvPrefix = DefaultEnvPrefix
+	}
+
+	// Every load uses its own viper instance, so configs loaded concurrently never share state
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if opts.ConfigPath != "" {
+		v.SetConfigFile(opts.ConfigPath)
+	} else {
+		v.SetConfigName("config")
+		v.AddConfigPath("config") // Fallback path
+	}
+
+	// Environment variables override any key, with nested keys mapped from . to _
+	v.SetEnvPrefix(opts.EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+
+	// Set default values
+	for key, value := range defaults {
+		v.SetDefault(key, value)
+	}
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+	base, err := readLayer(v.ConfigFileUsed())
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Flags != nil {
+		if err := v.BindPFlags(opts.Flags); err != nil {
+			return nil, fmt.Errorf("failed to bind flags: %w", err)
+		}
+	}
+
+	// The profile is chosen after env vars and flags are in place so they can select it
+	profilePath := ProfilePath(v.ConfigFileUsed(), v.GetString("app.env"))
+	var profile *viper.Viper
+	if _, err := os.Stat(profilePath); err == nil {
+		if profile, err = readLayer(profilePath); err != nil {
+			return nil, err
+		}
+		if err := v.MergeConfigMap(profile.AllSettings()); err != nil {
+			return nil, fmt.Errorf("failed to merge profile %s: %w", profilePath, err)
+		}
+	}
+
+	// Defaults have the lowest precedence whenever they are set, so they can follow app.env
+	if v.GetString("app.env") == "prd" {
+		for key, value := range prdDefaults {
+			v.SetDefault(key, value)
+		}
+	}
+
+	var config AppConfig
+	if err := v.Unmarshal(&config, viper.DecodeHook(decodeHook())); err != nil {
+		return nil, err
+	}
+	if err := resolveSecrets(context.Background(), reflect.ValueOf(&config).Elem(), "", opts.SecretProviders); err != nil {
+		return nil, err
+	}
+
+	sources := make(Sources)
+	for _, key := range v.AllKeys() {
+		sources[key] = layerOf(key, opts, base, profile)
+	}
+	return &snapshot{
+		config:   &config,
+		sources:  sources,
+		settings: v.AllSettings(),
+		file:     v.ConfigFileUsed(),
+		opts:     opts,
+		loadedAt: time.Now(),
+	}, nil
+}
+
+// ProfilePath returns the profile file of a base config file for an environment,
+// e.g. config/config.yaml and prd give config/config.prd.yaml.
+func ProfilePath(basePath, env string) string {
+	if basePath == "" || env == "" {
+		return ""
+	}
+	ext := filepath.Ext(basePath)
+	return strings.TrimSuffix(basePath, ext) + "." + env + ext
+}
+
+// readLayer reads a single config file on its own, to tell which keys it sets.
+func readLayer(path string) (*viper.Viper, error) {
+	layer := viper.New()
+	layer.SetConfigFile(path)
+	if err := layer.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+	return layer, nil
+}
+
+// layerOf returns the highest layer that sets key.
+func layerOf(key string, opts LoadOptions, base, profile *viper.Viper) string {
+	if opts.Flags != nil {
+		if flag := opts.Flags.Lookup(key); flag != nil && flag.Changed {
+			return LayerFlag
+		}
+	}
+	if _, ok := os.LookupEnv(EnvVar(opts.EnvPrefix, key)); ok {
+		return LayerEnv
+	}
+	if profile != nil && profile.IsSet(key) {
+		return LayerProfile
+	}
+	if base != nil && base.IsSet(key) {
+		return LayerFile
+	}
+	return LayerDefault
+}
+
+// EnvVar returns the environment variable overriding a config key, e.g. NEODATA_APP_PORT for app.port.
+func EnvVar(prefix, key string) string {
+	return strings.ToUpper(prefix + "_" + strings.ReplaceAll(key, ".", "_"))
+}
+
+// RegisterFlags defines a flag for every string and integer config key, named after the key
+// (--app.port, --database.host, ...). Pass the parsed flag set in LoadOptions.Flags.
+func RegisterFlags(fs *pflag.FlagSet) {
+	keys := make([]string, 0, len(defaults))
+	for key := range defaults {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if fs.Lookup(key) != nil {
+			continue
+		}
+		usage := fmt.Sprintf("overrides %s (env %s)", key, EnvVar(DefaultEnvPrefix, key))
+		switch value := defaults[key].(type) {
+		case int:
+			fs.Int(key, value, usage)
+		case string:
+			fs.String(key, value, usage)
+		}
+	}
+}
+
+// decodeHook extends viper's hooks so that durations given as plain numbers, as env vars and flags
+// provide them, decode like the integers of the YAML files.
+func decodeHook() mapstructure.DecodeHookFunc {
+	return mapstructure.ComposeDecodeHookFunc(
+		numericDurationHook,
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+	)
+}
+
+// numericDurationHook decodes a numeric string into a time.Duration holding that number.
+func numericDurationHook(from reflect.Type, to reflect.Type, data any) (any, error) {
+	if from.Kind() != reflect.String || to != reflect.TypeOf(time.Duration(0)) {
+		return data, nil
+	}
+	if n, err := strconv.ParseInt(data.(string), 10, 64); err == nil {
+		return time.Duration(n), nil
+	}
+	return data, nil
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+func TestLoadSamplingDefaults(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		env  string
+		vars map[string]string
+		want LogSamplingConfig
+	}{
+		{name: "dev", env: "dev", want: LogSamplingConfig{Tick: 1}},
+		{name: "prd", env: "prd", want: LogSamplingConfig{Initial: 100, Thereafter: 100, Tick: 1}},
+		{
+			name: "env var",
+			env:  "prd",
+			vars: map[string]string{"NEODATA_LOGGER_SAMPLING_THEREAFTER": "0"},
+			want: LogSamplingConfig{Initial: 100, Tick: 1},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.vars {
+				t.Setenv(key, value)
+			}
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte("app:\n  name: test\n  env: "+tt.env+"\n"), 0o644); err != nil {
+				t.Fatalf("write config: %v", err)
+			}
+
+			cfg, _, err := LoadConfigWithOptions(LoadOptions{ConfigPath: path})
+			if err != nil {
+				t.Fatalf("LoadConfigWithOptions() error = %v", err)
+			}
+			if cfg.Logger.Sampling == nil || *cfg.Logger.Sampling != tt.want {
+				t.Errorf("logger.sampling = %+v, want %+v", cfg.Logger.Sampling, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadLayers(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	base := "app:\n  name: orders\n  port: 9000\ndatabase:\n  host: db\n  user: orders\n"
+	if err := os.WriteFile(path, []byte(base), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if err := os.WriteFile(ProfilePath(path, "prd"), []byte("database:\n  host: db-prd\n  user: orders-prd\n"), 0o644); err != nil {
+		t.Fatalf("write profile: %v", err)
+	}
+	// The env var selects the profile and overrides a key of it
+	t.Setenv("NEODATA_APP_ENV", "prd")
+	t.Setenv("NEODATA_DATABASE_USER", "orders-env")
+
+	flags := pflag.NewFlagSet("orders", pflag.ContinueOnError)
+	RegisterFlags(flags)
+	if err := flags.Parse([]string{"--app.port=9100"}); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	cfg, sources, err := LoadConfigWithOptions(LoadOptions{ConfigPath: path, Flags: flags})
+	if err != nil {
+		t.Fatalf("LoadConfigWithOptions() error = %v", err)
+	}
+
+	tests := []struct {
+		key   string
+		got   any
+		want  any
+		layer string
+	}{
+		{"redis.address", cfg.Redis.Address, "localhost:6379", LayerDefault},
+		{"app.name", cfg.App.Name, "orders", LayerFile},
+		{"database.host", cfg.Database.Host, "db-prd", LayerProfile},
+		{"database.user", cfg.Database.User, "orders-env", LayerEnv},
+		{"app.env", cfg.App.Env, "prd", LayerEnv},
+		{"app.port", cfg.App.Port, 9100, LayerFlag},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.key, tt.got, tt.want)
+		}
+		if sources[tt.key] != tt.layer {
+			t.Errorf("%s comes from %q, want %q", tt.key, sources[tt.key], tt.layer)
+		}
+	}
+}
+
+func TestLoadEnvPrefix(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("app:\n  name: orders\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	t.Setenv("ORDERS_APP_PORT", "9200")
+	t.Setenv("NEODATA_APP_PORT", "9300")
+
+	cfg, _, err := LoadConfigWithOptions(LoadOptions{ConfigPath: path, EnvPrefix: "ORDERS"})
+	if err != nil {
+		t.Fatalf("LoadConfigWithOptions() error = %v", err)
+	}
+	if cfg.App.Port != 9200 {
+		t.Errorf("app.port = %d, want 9200 from ORDERS_APP_PORT", cfg.App.Port)
+	}
+	if got := EnvVar("ORDERS", "database.ssl_mode"); got != "ORDERS_DATABASE_SSL_MODE" {
+		t.Errorf("EnvVar() = %q, want ORDERS_DATABASE_SSL_MODE", got)
+	}
+}
+
+func TestLoadNumericDurations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("app:\n  name: orders\n  grace_period: 5\n  read_timeout: 2s\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	cfg, _, err := LoadConfigWithOptions(LoadOptions{ConfigPath: path})
+	if err != nil {
+		t.Fatalf("LoadConfigWithOptions() error = %v", err)
+	}
+	if cfg.App.GracePeriod != 5 || cfg.App.ReadTimeout != 2*time.Second {
+		t.Errorf("grace_period = %d, read_timeout = %s, want 5 and 2s", cfg.App.GracePeriod, cfg.App.ReadTimeout)
+	}
+}
@@ -0,0 +1,158 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// RedactedValue replaces the secrets of a redacted configuration.
+const RedactedValue = "[REDACTED]"
+
+// SecretRef is a reference to a secret, written <scheme>://<path>[#<key>] in a secret config value:
+//
+//	file:///run/secrets/db_password  -> Scheme "file",  Path "/run/secrets/db_password"
+//	env://DB_PASS                    -> Scheme "env",   Path "DB_PASS"
+//	vault://secret/data/db#password  -> Scheme "vault", Path "secret/data/db", Key "password"
+type SecretRef struct {
+	Scheme string
+	Path   string
+	Key    string // Optional fragment selecting a key inside the secret
+}
+
+func (r SecretRef) String() string {
+	ref := r.Scheme + "://" + r.Path
+	if r.Key != "" {
+		ref += "#" + r.Key
+	}
+	return ref
+}
+
+// SecretProvider resolves the secret references of one scheme.
+type SecretProvider interface {
+	// Scheme returns the scheme handled by the provider, e.g. vault
+	Scheme() string
+	// Resolve returns the value of the secret
+	Resolve(ctx context.Context, ref SecretRef) (string, error)
+}
+
+// FileSecretProvider resolves file:// references to the content of the file, e.g. a Docker
+// or Kubernetes secret, without the trailing newline.
+type FileSecretProvider struct{}
+
+func (FileSecretProvider) Scheme() string { return "file" }
+
+func (FileSecretProvider) Resolve(_ context.Context, ref SecretRef) (string, error) {
+	data, err := os.ReadFile(ref.Path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// EnvSecretProvider resolves env:// references to the value of the environment variable.
+type EnvSecretProvider struct{}
+
+func (EnvSecretProvider) Scheme() string { return "env" }
+
+func (EnvSecretProvider) Resolve(_ context.Context, ref SecretRef) (string, error) {
+	value, ok := os.LookupEnv(ref.Path)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", ref.Path)
+	}
+	return value, nil
+}
+
+// secretRefPattern matches values written as secret references
+var secretRefPattern = regexp.MustCompile(`^([a-z][a-z0-9+.-]*)://([^#]*)(?:#(.*))?$`)
+
+// parseSecretRef parses value if it is written as a secret reference.
+func parseSecretRef(value string) (SecretRef, bool) {
+	m := secretRefPattern.FindStringSubmatch(value)
+	if m == nil {
+		return SecretRef{}, false
+	}
+	return SecretRef{Scheme: m[1], Path: m[2], Key: m[3]}, true
+}
+
+// resolveSecrets replaces the secret references of the fields of the struct v tagged secret:"true"
+// by their values, using the built-in file and env providers and the given ones. A reference to a
+// scheme without provider is an error rather than being used as the literal secret.
+func resolveSecrets(ctx context.Context, v reflect.Value, prefix string, providers []SecretProvider) error {
+	registry := map[string]SecretProvider{}
+	for _, provider := range append([]SecretProvider{FileSecretProvider{}, EnvSecretProvider{}}, providers...) {
+		registry[provider.Scheme()] = provider
+	}
+
+	return walkSecrets(v, prefix, func(key string, field reflect.Value) error {
+		ref, ok := parseSecretRef(field.String())
+		if !ok {
+			return nil
+		}
+		provider, ok := registry[ref.Scheme]
+		if !ok {
+			return fmt.Errorf("failed to resolve %s: no secret provider for scheme %s", key, ref.Scheme)
+		}
+		value, err := provider.Resolve(ctx, ref)
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s from %s: %w", key, ref, err)
+		}
+		field.SetString(value)
+		return nil
+	})
+}
+
+// walkSecrets calls fn with the config key of every string field tagged secret:"true" of the
+// struct v and of its nested struct values.
+func walkSecrets(v reflect.Value, prefix string, fn func(key string, field reflect.Value) error) error {
+	for i := 0; i < v.NumField(); i++ {
+		field, typ := v.Field(i), v.Type().Field(i)
+		key, _, _ := strings.Cut(typ.Tag.Get("mapstructure"), ",")
+		if key == "" {
+			key = typ.Name
+		}
+		key = prefix + strings.ToLower(key)
+
+		switch {
+		case field.Kind() == reflect.Struct:
+			if err := walkSecrets(field, key+".", fn); err != nil {
+				return err
+			}
+		case field.Kind() == reflect.String && typ.Tag.Get("secret") == "true":
+			if err := fn(key, field); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Redacted returns a copy of the configuration whose non-empty secrets are replaced by RedactedValue.
+func (c *AppConfig) Redacted() *AppConfig {
+	redacted := *c
+	_ = walkSecrets(reflect.ValueOf(&redacted).Elem(), "", func(_ string, field reflect.Value) error {
+		if field.String() != "" {
+			field.SetString(RedactedValue)
+		}
+		return nil
+	})
+	return &redacted
+}
+
+// plainAppConfig has the fields of AppConfig without its methods, to encode it without recursion.
+type plainAppConfig AppConfig
+
+// String formats the redacted configuration, so secrets never reach the logs through fmt.
+func (c AppConfig) String() string {
+	return fmt.Sprintf("%+v", (*plainAppConfig)(c.Redacted()))
+}
+
+// MarshalJSON encodes the redacted configuration, so secrets never reach JSON dumps or
+// zap.Any fields.
+func (c AppConfig) MarshalJSON() ([]byte, error) {
+	return json.Marshal((*plainAppConfig)(c.Redacted()))
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// vaultProvider serves the secrets of a map, keyed by path#key.
+type vaultProvider map[string]string
+
+func (vaultProvider) Scheme() string { return "vault" }
+
+func (p vaultProvider) Resolve(_ context.Context, ref SecretRef) (string, error) {
+	value, ok := p[ref.Path+"#"+ref.Key]
+	if !ok {
+		return "", fmt.Errorf("secret %s not found", ref)
+	}
+	return value, nil
+}
+
+// loadSecrets loads a config whose secrets are the given values.
+func loadSecrets(t *testing.T, password, jwtSecret string, providers ...SecretProvider) (*AppConfig, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := fmt.Sprintf("app:\n  name: orders\ndatabase:\n  password: %q\nauth:\n  jwtSecret: %q\n", password, jwtSecret)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	cfg, _, err := LoadConfigWithOptions(LoadOptions{ConfigPath: path, SecretProviders: providers})
+	return cfg, err
+}
+
+func TestResolveSecrets(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "db_password")
+	if err := os.WriteFile(secretFile, []byte("s3cret\n"), 0o600); err != nil {
+		t.Fatalf("write secret: %v", err)
+	}
+	t.Setenv("JWT_SECRET", "jwt-s3cret")
+
+	tests := []struct {
+		name      string
+		password  string
+		providers []SecretProvider
+		want      string
+	}{
+		{name: "literal", password: "plain", want: "plain"},
+		{name: "file", password: "file://" + secretFile, want: "s3cret"},
+		{name: "env", password: "env://JWT_SECRET", want: "jwt-s3cret"},
+		{name: "custom provider", password: "vault://secret/data/db#password", providers: []SecretProvider{vaultProvider{"secret/data/db#password": "v4ult"}}, want: "v4ult"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := loadSecrets(t, tt.password, "env://JWT_SECRET", tt.providers...)
+			if err != nil {
+				t.Fatalf("LoadConfigWithOptions() error = %v", err)
+			}
+			if cfg.Database.Password != tt.want {
+				t.Errorf("database.password = %q, want %q", cfg.Database.Password, tt.want)
+			}
+			if cfg.Auth.JwtSecret != "jwt-s3cret" {
+				t.Errorf("auth.jwtSecret = %q, want jwt-s3cret", cfg.Auth.JwtSecret)
+			}
+		})
+	}
+}
+
+func TestResolveSecretsErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		want     string
+	}{
+		{name: "unknown scheme", password: "vault://secret/data/db#password", want: "no secret provider for scheme vault"},
+		{name: "missing file", password: "file://" + filepath.Join(t.TempDir(), "missing"), want: "failed to resolve database.password"},
+		{name: "unset env var", password: "env://NEODATA_TEST_UNSET_SECRET", want: "NEODATA_TEST_UNSET_SECRET is not set"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadSecrets(t, tt.password, "")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("LoadConfigWithOptions() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := &AppConfig{}
+	cfg.App.Name = "orders"
+	cfg.Database.Password, cfg.Auth.JwtSecret = "s3cret", "jwt-s3cret"
+
+	redacted := cfg.Redacted()
+	if redacted.Database.Password != RedactedValue || redacted.Auth.JwtSecret != RedactedValue || redacted.App.Secret != "" {
+		t.Errorf("Redacted() = %+v, want non-empty secrets redacted", redacted)
+	}
+	if cfg.Database.Password != "s3cret" {
+		t.Error("Redacted() modified the configuration")
+	}
+
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	for _, out := range []string{string(data), cfg.String(), fmt.Sprintf("%v", *cfg)} {
+		if strings.Contains(out, "s3cret") {
+			t.Errorf("%s reveals a secret", out)
+		}
+	}
+}
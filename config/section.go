@@ -0,0 +1,186 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// SectionDefaulter is implemented by custom section types that have default values.
+// SetDefaults runs before the section is decoded, so configured values override the defaults.
+type SectionDefaulter interface {
+	SetDefaults()
+}
+
+// sectionSource is implemented by config managers that keep every loaded setting.
+type sectionSource interface {
+	snapshot() *snapshot
+	registerSection(name string, validate func(*snapshot) error)
+}
+
+// Section decodes and validates the custom config section name into the service's own struct,
+// using its mapstructure and validate tags:
+//
+//	type PaymentsConfig struct {
+//		Provider string        `mapstructure:"provider" validate:"required"`
+//		Timeout  time.Duration `mapstructure:"timeout" validate:"gt=0"`
+//		APIKey   string        `mapstructure:"api_key" secret:"true"`
+//	}
+//
+//	payments, err := config.Section[PaymentsConfig](app.ConfigManager, "payments")
+//
+// The section follows the layering of AppConfig: SetDefaults, the config files and profile,
+// environment variables such as NEODATA_PAYMENTS_PROVIDER and flags such as --payments.provider.
+// Secret references are resolved like for AppConfig. The section is validated again on every
+// reload, and Section returns its latest value; see WatchSection to be notified of changes.
+func Section[T any](manager ConfigManager, name string) (*T, error) {
+	source, ok := manager.(sectionSource)
+	if !ok {
+		return nil, fmt.Errorf("config manager %T does not support custom sections", manager)
+	}
+
+	section, err := decodeSection[T](source.snapshot(), name)
+	if err != nil {
+		return nil, err
+	}
+	source.registerSection(name, func(snap *snapshot) error {
+		_, err := decodeSection[T](snap, name)
+		return err
+	})
+	return section, nil
+}
+
+// WatchSection reads the custom section like Section and calls fn with the previous and the new
+// value whenever a reload changes it.
+func WatchSection[T any](manager ConfigManager, name string, fn func(old, new *T)) (*T, error) {
+	section, err := Section[T](manager, name)
+	if err != nil {
+		return nil, err
+	}
+
+	reloadable, ok := manager.(Reloadable)
+	if !ok {
+		return section, nil // The section never changes
+	}
+	source := manager.(sectionSource)
+	current := section
+	reloadable.OnChange(func(_, _ *AppConfig) {
+		// The section was validated before the snapshot was swapped in
+		updated, err := decodeSection[T](source.snapshot(), name)
+		if err != nil || reflect.DeepEqual(current, updated) {
+			return
+		}
+		old := current
+		current = updated
+		fn(old, updated)
+	})
+	return section, nil
+}
+
+// decodeSection decodes the section name of a snapshot into a new T.
+func decodeSection[T any](snap *snapshot, name string) (*T, error) {
+	section := new(T)
+	typ := reflect.TypeOf(section).Elem()
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("config section %s: %s is not a struct", name, typ)
+	}
+	if defaulter, ok := any(section).(SectionDefaulter); ok {
+		defaulter.SetDefaults()
+	}
+
+	// Env vars and flags only reach viper for keys it already knows, so apply them here for
+	// keys the config files do not mention
+	settings, _ := snap.settings[strings.ToLower(name)].(map[string]any)
+	settings = copySettings(settings)
+	for _, key := range sectionKeys(typ, "") {
+		fullKey := strings.ToLower(name) + "." + key
+		if snap.opts.Flags != nil {
+			if flag := snap.opts.Flags.Lookup(fullKey); flag != nil && flag.Changed {
+				setSetting(settings, key, flag.Value.String())
+				continue
+			}
+		}
+		if value, ok := os.LookupEnv(EnvVar(snap.opts.EnvPrefix, fullKey)); ok {
+			setSetting(settings, key, value)
+		}
+	}
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook:       decodeHook(),
+		WeaklyTypedInput: true, // Like viper, env vars and flags are strings
+		Result:           section,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := decoder.Decode(settings); err != nil {
+		return nil, fmt.Errorf("failed to decode config section %s: %w", name, err)
+	}
+
+	if err := resolveSecrets(context.Background(), reflect.ValueOf(section).Elem(), strings.ToLower(name)+".", snap.opts.SecretProviders); err != nil {
+		return nil, err
+	}
+
+	fields, err := validateStruct(section, strings.ToLower(name)+".")
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) > 0 {
+		return nil, &ValidationError{Fields: fields}
+	}
+	return section, nil
+}
+
+// sectionKeys returns the dotted keys of the leaf fields of the struct type typ.
+func sectionKeys(typ reflect.Type, prefix string) []string {
+	var keys []string
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		key, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		if key == "" {
+			key = field.Name
+		}
+		key = prefix + strings.ToLower(key)
+
+		if field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeOf(time.Time{}) {
+			keys = append(keys, sectionKeys(field.Type, key+".")...)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// copySettings deep copies a settings tree, so overrides never modify a snapshot.
+func copySettings(settings map[string]any) map[string]any {
+	copied := make(map[string]any, len(settings))
+	for key, value := range settings {
+		if nested, ok := value.(map[string]any); ok {
+			value = copySettings(nested)
+		}
+		copied[key] = value
+	}
+	return copied
+}
+
+// setSetting sets the dotted key of a settings tree, creating the intermediate maps.
+func setSetting(settings map[string]any, key string, value any) {
+	parts := strings.Split(key, ".")
+	for _, part := range parts[:len(parts)-1] {
+		nested, ok := settings[part].(map[string]any)
+		if !ok {
+			nested = map[string]any{}
+			settings[part] = nested
+		}
+		settings = nested
+	}
+	settings[parts[len(parts)-1]] = value
+}
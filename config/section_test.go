@@ -0,0 +1,118 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type paymentsConfig struct {
+	Provider string        `mapstructure:"provider" validate:"required"`
+	Timeout  time.Duration `mapstructure:"timeout" validate:"gt=0"`
+	APIKey   string        `mapstructure:"api_key" secret:"true"`
+	Retry    struct {
+		Attempts int `mapstructure:"attempts" validate:"gte=0"`
+	} `mapstructure:"retry"`
+}
+
+func (c *paymentsConfig) SetDefaults() {
+	c.Timeout = 5 * time.Second
+	c.Retry.Attempts = 3
+}
+
+// writeSection writes a config file with the given payments section.
+func writeSection(t *testing.T, path, payments string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte("app:\n  name: orders\nlogger:\n  log_level: info\npayments:\n"+payments), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+}
+
+func TestSection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeSection(t, path, "  provider: stripe\n  api_key: env://PAYMENTS_KEY\n")
+	t.Setenv("PAYMENTS_KEY", "sk_test")
+	t.Setenv("NEODATA_PAYMENTS_RETRY_ATTEMPTS", "5") // Not in the file
+
+	manager, err := NewConfigManager(path)
+	if err != nil {
+		t.Fatalf("NewConfigManager() error = %v", err)
+	}
+	payments, err := Section[paymentsConfig](manager, "payments")
+	if err != nil {
+		t.Fatalf("Section() error = %v", err)
+	}
+	if payments.Provider != "stripe" || payments.Timeout != 5*time.Second || payments.APIKey != "sk_test" || payments.Retry.Attempts != 5 {
+		t.Errorf("Section() = %+v, want the file, default, secret and env var values", payments)
+	}
+}
+
+func TestSectionValidation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeSection(t, path, "  timeout: 0s\n")
+	manager, err := NewConfigManager(path)
+	if err != nil {
+		t.Fatalf("NewConfigManager() error = %v", err)
+	}
+
+	_, err = Section[paymentsConfig](manager, "payments")
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Section() error = %v, want a *ValidationError", err)
+	}
+	keys := map[string]bool{}
+	for _, field := range validationErr.Fields {
+		keys[field.Key] = true
+	}
+	if len(keys) != 2 || !keys["payments.provider"] || !keys["payments.timeout"] {
+		t.Errorf("invalid keys = %v, want payments.provider and payments.timeout", keys)
+	}
+
+	if _, err := Section[string](manager, "payments"); err == nil {
+		t.Error("Section() of a non-struct type error = nil")
+	}
+	if _, err := Section[paymentsConfig](NewStaticConfigManager(&AppConfig{}), "payments"); err == nil {
+		t.Error("Section() of a static config manager error = nil")
+	}
+}
+
+func TestWatchSection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeSection(t, path, "  provider: stripe\n")
+	manager, err := NewConfigManager(path)
+	if err != nil {
+		t.Fatalf("NewConfigManager() error = %v", err)
+	}
+
+	var changes []string
+	if _, err := WatchSection(manager, "payments", func(old, new *paymentsConfig) {
+		changes = append(changes, old.Provider+" -> "+new.Provider)
+	}); err != nil {
+		t.Fatalf("WatchSection() error = %v", err)
+	}
+
+	// Reloads leaving the section as is do not notify
+	if err := manager.Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	writeSection(t, path, "  provider: adyen\n")
+	if err := manager.Reload(); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+
+	// An invalid section rejects the reload
+	writeSection(t, path, "  provider: \"\"\n")
+	if err := manager.Reload(); err == nil {
+		t.Fatal("Reload() of an invalid section error = nil")
+	}
+	if payments, _ := Section[paymentsConfig](manager, "payments"); payments == nil || payments.Provider != "adyen" {
+		t.Errorf("Section() after the rejected reload = %+v, want adyen", payments)
+	}
+
+	if len(changes) != 1 || changes[0] != "stripe -> adyen" {
+		t.Errorf("changes = %v, want [stripe -> adyen]", changes)
+	}
+}
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/go-playground/validator"
+	"github.com/neodata-io/neodata-go/util"
+)
+
+// Top level config sections, validated only when the service uses them.
+const (
+	SectionApp       = "app"
+	SectionDatabase  = "database"
+	SectionAuth      = "auth"
+	SectionMessaging = "messaging"
+	SectionLogger    = "logger"
+	SectionRedis     = "redis"
+)
+
+// alwaysValidated are the sections every service needs.
+var alwaysValidated = []string{SectionApp, SectionLogger}
+
+// FieldError is an invalid config value.
+type FieldError struct {
+	Key     string // Dotted config key, e.g. database.host or messaging.streams[0].stream_name
+	Rule    string // Failed validate rule, e.g. required
+	Message string
+}
+
+// ValidationError lists every invalid config value.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		msgs[i] = field.Key + ": " + field.Message
+	}
+	return "invalid configuration: " + strings.Join(msgs, "; ")
+}
+
+// Validate checks the validate tags of the app and logger sections and of the given sections,
+// e.g. Validate(cfg, SectionDatabase) when the service uses PostgreSQL. Every invalid key is
+// reported in a single *ValidationError.
+func Validate(cfg *AppConfig, sections ...string) error {
+	return ValidateSections(cfg, slices.Concat(alwaysValidated, sections)...)
+}
+
+// ValidateSections checks the validate tags of the given sections only, e.g. for a client that
+// needs the database section but not an app or logger section. Every invalid key is reported in
+// a single *ValidationError.
+func ValidateSections(cfg *AppConfig, sections ...string) error {
+	if cfg == nil {
+		return fmt.Errorf("invalid configuration: config is nil")
+	}
+
+	enabled := make(map[string]bool)
+	for _, section := range sections {
+		enabled[section] = true
+	}
+
+	fields, err := validateStruct(cfg, "")
+	if err != nil {
+		return err
+	}
+
+	var enabledFields []FieldError
+	for _, field := range fields {
+		section, _, _ := strings.Cut(field.Key, ".")
+		if enabled[section] {
+			enabledFields = append(enabledFields, field)
+		}
+	}
+	if len(enabledFields) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: enabledFields}
+}
+
+// validateStruct checks the validate tags of the struct pointed to by v and returns the invalid
+// fields keyed by their config key, prefixed with prefix.
+func validateStruct(v any, prefix string) ([]FieldError, error) {
+	err := util.GetValidator().Struct(v)
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return nil, err
+	}
+
+	typ := reflect.TypeOf(v).Elem()
+	fields := make([]FieldError, 0, len(validationErrs))
+	for _, fe := range validationErrs {
+		key := prefix + configKey(typ, fe.StructNamespace())
+		fields = append(fields, FieldError{Key: key, Rule: fe.Tag(), Message: ruleMessage(fe)})
+	}
+	return fields, nil
+}
+
+// indexSuffix matches the slice index of a namespace element, e.g. Streams[0]
+var indexSuffix = regexp.MustCompile(`^(\w+)(\[\d+\])$`)
+
+// configKey maps a validator namespace of the struct type typ, such as AppConfig.Messaging.Streams[0].StreamName,
+// to the config key messaging.streams[0].stream_name using the mapstructure tags.
+func configKey(typ reflect.Type, namespace string) string {
+	parts := strings.Split(namespace, ".")[1:] // Drop the root type
+
+	keys := make([]string, 0, len(parts))
+	for _, part := range parts {
+		name, index := part, ""
+		if m := indexSuffix.FindStringSubmatch(part); m != nil {
+			name, index = m[1], m[2]
+		}
+		for typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice {
+			typ = typ.Elem()
+		}
+		field, ok := typ.FieldByName(name)
+		if !ok {
+			keys = append(keys, strings.ToLower(part))
+			continue
+		}
+		key, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		if key == "" {
+			key = name
+		}
+		keys = append(keys, strings.ToLower(key)+index)
+		typ = field.Type
+	}
+	return strings.Join(keys, ".")
+}
+
+// ruleMessage describes a failed validate rule.
+func ruleMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "oneof":
+		return "must be one of " + fe.Param()
+	case "min", "gte":
+		return "must be at least " + fe.Param()
+	case "max", "lte":
+		return "must be at most " + fe.Param()
+	case "gt":
+		return "must be greater than " + fe.Param()
+	case "url":
+		return "must be a valid URL"
+	default:
+		return fmt.Sprintf("failed the %s rule", fe.Tag())
+	}
+}
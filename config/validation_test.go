@@ -0,0 +1,93 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateGracePeriod(t *testing.T) {
+	for _, tt := range []struct {
+		gracePeriod int
+		valid       bool
+	}{
+		{gracePeriod: 0, valid: true}, // Uses the default grace period
+		{gracePeriod: 10, valid: true},
+		{gracePeriod: -1, valid: false},
+	} {
+		cfg := &AppConfig{}
+		cfg.App.Name, cfg.App.Port, cfg.App.Env = "test", 8080, "dev"
+		cfg.Logger.LogLevel = "info"
+		cfg.App.GracePeriod = time.Duration(tt.gracePeriod)
+		if err := Validate(cfg); (err == nil) != tt.valid {
+			t.Errorf("Validate() with grace period %d = %v, want valid %t", tt.gracePeriod, err, tt.valid)
+		}
+	}
+}
+
+func TestValidateSections(t *testing.T) {
+	cfg := &AppConfig{}
+	cfg.Database.Type, cfg.Database.Host, cfg.Database.Port = "postgres", "localhost", 5432
+	cfg.Database.Name, cfg.Database.User = "orders", "orders"
+
+	if err := ValidateSections(cfg, SectionDatabase); err != nil {
+		t.Errorf("ValidateSections(database) = %v, want nil without app and logger sections", err)
+	}
+
+	err := Validate(cfg, SectionDatabase)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Validate() error = %v, want a *ValidationError", err)
+	}
+	for _, field := range validationErr.Fields {
+		if section, _, _ := strings.Cut(field.Key, "."); section != SectionApp && section != SectionLogger {
+			t.Errorf("Validate() reported %s, want app and logger keys only", field.Key)
+		}
+	}
+}
+
+func TestValidateReportsEachSection(t *testing.T) {
+	valid := func() *AppConfig {
+		cfg := &AppConfig{}
+		cfg.App.Name, cfg.App.Port, cfg.App.Env = "orders", 8080, "dev"
+		cfg.Logger.LogLevel = "info"
+		cfg.Database.Type, cfg.Database.Host, cfg.Database.Port = "postgres", "localhost", 5432
+		cfg.Database.Name, cfg.Database.User = "orders", "orders"
+		cfg.Auth.JwtSecret, cfg.Auth.TokenExpiry = "secret", time.Hour
+		cfg.Messaging.PubsubBackend, cfg.Messaging.PubsubBroker = "nats", "nats://localhost:4222"
+		cfg.Redis.Address = "localhost:6379"
+		return cfg
+	}
+	sections := []string{SectionDatabase, SectionAuth, SectionMessaging, SectionRedis}
+	if err := Validate(valid(), sections...); err != nil {
+		t.Fatalf("Validate() of a valid config = %v", err)
+	}
+
+	tests := []struct {
+		key    string
+		rule   string
+		breaks func(cfg *AppConfig)
+	}{
+		{"app.port", "max", func(cfg *AppConfig) { cfg.App.Port = 70000 }},
+		{"logger.log_level", "oneof", func(cfg *AppConfig) { cfg.Logger.LogLevel = "verbose" }},
+		{"database.host", "required", func(cfg *AppConfig) { cfg.Database.Host = "" }},
+		{"auth.tokenexpiry", "gt", func(cfg *AppConfig) { cfg.Auth.TokenExpiry = 0 }},
+		{"messaging.pubsub_broker", "url", func(cfg *AppConfig) { cfg.Messaging.PubsubBroker = "localhost" }},
+		{"redis.address", "required", func(cfg *AppConfig) { cfg.Redis.Address = "" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			cfg := valid()
+			tt.breaks(cfg)
+			err := Validate(cfg, sections...)
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) || len(validationErr.Fields) != 1 {
+				t.Fatalf("Validate() error = %v, want a single invalid key", err)
+			}
+			if field := validationErr.Fields[0]; field.Key != tt.key || field.Rule != tt.rule || !strings.Contains(err.Error(), tt.key) {
+				t.Errorf("Validate() = %+v, want %s failing %s", field, tt.key, tt.rule)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"maps"
+	"net/http"
+)
+
+// Code is a stable, machine-readable error code, safe to expose to clients.
+type Code string
+
+const (
+	CodeBadRequest      Code = "bad_request"
+	CodeValidation      Code = "validation_failed"
+	CodeUnauthorized    Code = "unauthorized"
+	CodeForbidden       Code = "forbidden"
+	CodeNotFound        Code = "not_found"
+	CodeConflict        Code = "conflict"
+	CodeTooManyRequests Code = "too_many_requests"
+	CodeTimeout         Code = "timeout"
+	CodeUnavailable     Code = "unavailable"
+	CodeInternal        Code = "internal"
+)
+
+// codeStatus maps the codes to their HTTP status.
+var codeStatus = map[Code]int{
+	CodeBadRequest:      http.StatusBadRequest,
+	CodeValidation:      http.StatusBadRequest,
+	CodeUnauthorized:    http.StatusUnauthorized,
+	CodeForbidden:       http.StatusForbidden,
+	CodeNotFound:        http.StatusNotFound,
+	CodeConflict:        http.StatusConflict,
+	CodeTooManyRequests: http.StatusTooManyRequests,
+	CodeTimeout:         http.StatusGatewayTimeout,
+	CodeUnavailable:     http.StatusServiceUnavailable,
+	CodeInternal:        http.StatusInternalServerError,
+}
+
+// Status returns the HTTP status of the code, 500 for unknown codes.
+func (c Code) Status() int {
+	if status, ok := codeStatus[c]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// Sentinel errors, one per code. errors.Is matches any AppError with the same code:
+//
+//	if errors.Is(err, apperrors.ErrNotFound) { ... }
+var (
+	ErrBadRequest      = &AppError{Code: CodeBadRequest, Status: http.StatusBadRequest, Message: "bad request"}
+	ErrValidation      = &AppError{Code: CodeValidation, Status: http.StatusBadRequest, Message: "validation failed"}
+	ErrUnauthorized    = &AppError{Code: CodeUnauthorized, Status: http.StatusUnauthorized, Message: "unauthorized"}
+	ErrForbidden       = &AppError{Code: CodeForbidden, Status: http.StatusForbidden, Message: "forbidden"}
+	ErrNotFound        = &AppError{Code: CodeNotFound, Status: http.StatusNotFound, Message: "not found"}
+	ErrConflict        = &AppError{Code: CodeConflict, Status: http.StatusConflict, Message: "conflict"}
+	ErrTooManyRequests = &AppError{Code: CodeTooManyRequests, Status: http.StatusTooManyRequests, Message: "too many requests", Retryable: true}
+	ErrTimeout         = &AppError{Code: CodeTimeout, Status: http.StatusGatewayTimeout, Message: "timeout", Retryable: true}
+	ErrUnavailable     = &AppError{Code: CodeUnavailable, Status: http.StatusServiceUnavailable, Message: "unavailable", Retryable: true}
+	ErrInternal        = &AppError{Code: CodeInternal, Status: http.StatusInternalServerError, Message: "internal error"}
+)
+
+// AppError is an application error with a stable code, the HTTP status it maps to, a message for
+// clients, the wrapped cause and structured details.
+type AppError struct {
+	Code      Code
+	Status    int
+	Message   string
+	Cause     error
+	Details   map[string]any
+	Retryable bool // The operation may succeed if retried later
+}
+
+// New returns an AppError with the status of the code. Errors with the codes too_many_requests,
+// timeout and unavailable are retryable.
+func New(code Code, message string) *AppError {
+	return &AppError{
+		Code:      code,
+		Status:    code.Status(),
+		Message:   message,
+		Retryable: code == CodeTooManyRequests || code == CodeTimeout || code == CodeUnavailable,
+	}
+}
+
+// Wrap returns an AppError for code caused by err.
+func Wrap(err error, code Code, message string) *AppError {
+	return New(code, message).WithCause(err)
+}
+
+// BadRequest returns a 400 bad_request error.
+func BadRequest(message string) *AppError { return New(CodeBadRequest, message) }
+
+// Validation returns a 400 validation_failed error detailing the invalid fields, e.g. {"email": "is required"}.
+func Validation(message string, fields map[string]string) *AppError {
+	err := New(CodeValidation, message)
+	for field, problem := range fields {
+		err = err.WithDetail(field, problem)
+	}
+	return err
+}
+
+// Unauthorized returns a 401 unauthorized error.
+func Unauthorized(message string) *AppError { return New(CodeUnauthorized, message) }
+
+// Forbidden returns a 403 forbidden error.
+func Forbidden(message string) *AppError { return New(CodeForbidden, message) }
+
+// NotFound returns a 404 not_found error.
+func NotFound(message string) *AppError { return New(CodeNotFound, message) }
+
+// Conflict returns a 409 conflict error, e.g. for a duplicate key.
+func Conflict(message string) *AppError { return New(CodeConflict, message) }
+
+// TooManyRequests returns a retryable 429 too_many_requests error.
+func TooManyRequests(message string) *AppError { return New(CodeTooManyRequests, message) }
+
+// Timeout returns a retryable 504 timeout error.
+func Timeout(message string) *AppError { return New(CodeTimeout, message) }
+
+// Unavailable returns a retryable 503 unavailable error, e.g. when a dependency is down.
+func Unavailable(message string) *AppError { return New(CodeUnavailable, message) }
+
+// Internal returns a 500 internal error.
+func Internal(message string) *AppError { return New(CodeInternal, message) }
+
+func (e *AppError) Error() string {
+	message := e.Message
+	if message == "" {
+		message = string(e.Code)
+	}
+	if e.Cause != nil {
+		return fmt.Sprintf("%s: %v", message, e.Cause)
+	}
+	return message
+}
+
+// StatusCode returns the HTTP status of the error.
+func (e *AppError) StatusCode() int {
+	if e.Status == 0 {
+		return e.Code.Status()
+	}
+	return e.Status
+}
+
+// Unwrap returns the cause of the error.
+func (e *AppError) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports whether target is an AppError with the same code, which makes the sentinels match.
+func (e *AppError) Is(target error) bool {
+	t, ok := target.(*AppError)
+	return ok && t.Code == e.Code
+}
+
+// WithCause returns a copy of the error wrapping cause.
+func (e *AppError) WithCause(cause error) *AppError {
+	clone := e.clone()
+	clone.Cause = cause
+	return clone
+}
+
+// WithDetail returns a copy of the error with a detail added.
+func (e *AppError) WithDetail(key string, value any) *AppError {
+	clone := e.clone()
+	clone.Details[key] = value
+	return clone
+}
+
+// WithRetryable returns a copy of the error with the retryable flag set.
+func (e *AppError) WithRetryable(retryable bool) *AppError {
+	clone := e.clone()
+	clone.Retryable = retryable
+	return clone
+}
+
+// clone copies the error, so the builders never modify the sentinels.
+func (e *AppError) clone() *AppError {
+	clone := *e
+	clone.Details = make(map[string]any, len(e.Details)+1)
+	maps.Copy(clone.Details, e.Details)
+	return &clone
+}
+
+// As returns the first AppError in the chain of err. Errors of the legacy types, such as
+// NotFoundError, are converted to an AppError with their code.
+func As(err error) (*AppError, bool) {
+	var appErr *AppError
+	if stderrors.As(err, &appErr) {
+		return appErr, true
+	}
+	var legacy interface{ appError() *AppError }
+	if stderrors.As(err, &legacy) {
+		return legacy.appError(), true
+	}
+	return nil, false
+}
+
+// CodeOf returns the code of err, internal when err carries none.
+func CodeOf(err error) Code {
+	if appErr, ok := As(err); ok {
+		return appErr.Code
+	}
+	return CodeInternal
+}
+
+// IsRetryable reports whether err is an AppError marked as retryable.
+func IsRetryable(err error) bool {
+	appErr, ok := As(err)
+	return ok && appErr.Retryable
+}
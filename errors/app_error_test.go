@@ -0,0 +1,113 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestIsMatchesSentinels(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		sentinel error
+	}{
+		{"app error", NotFound("order 1 not found"), ErrNotFound},
+		{"wrapped app error", fmt.Errorf("load order: %w", Conflict("duplicate order")), ErrConflict},
+		{"app error with cause", Wrap(stderrors.New("dial tcp: refused"), CodeUnavailable, "database unavailable"), ErrUnavailable},
+		{"legacy not found", NotFoundError{Detail: "order 1"}, ErrNotFound},
+		{"legacy bad request", BadRequestError{Detail: "missing id"}, ErrBadRequest},
+		{"legacy unauthorized", UnauthorizedError{Detail: "no token"}, ErrUnauthorized},
+		{"legacy forbidden", ForbiddenError{Detail: "not the owner"}, ErrForbidden},
+		{"legacy internal", InternalServerError{Detail: "boom"}, ErrInternal},
+		{"wrapped legacy", fmt.Errorf("load order: %w", NotFoundError{Detail: "order 1"}), ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !stderrors.Is(tt.err, tt.sentinel) {
+				t.Errorf("errors.Is(%v, %v) = false, want true", tt.err, tt.sentinel)
+			}
+			if stderrors.Is(tt.err, ErrTimeout) {
+				t.Errorf("errors.Is(%v, ErrTimeout) = true, want false", tt.err)
+			}
+		})
+	}
+}
+
+func TestIsMatchesCause(t *testing.T) {
+	cause := stderrors.New("dial tcp: refused")
+	err := Wrap(cause, CodeUnavailable, "database unavailable")
+	if !stderrors.Is(err, cause) {
+		t.Error("errors.Is does not match the cause")
+	}
+	if got, want := err.Error(), "database unavailable: dial tcp: refused"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestAs(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantCode   Code
+		wantStatus int
+		wantOK     bool
+	}{
+		{"app error", Forbidden("not the owner"), CodeForbidden, http.StatusForbidden, true},
+		{"wrapped app error", fmt.Errorf("update: %w", Validation("invalid order", nil)), CodeValidation, http.StatusBadRequest, true},
+		{"legacy type", fmt.Errorf("load: %w", NotFoundError{Detail: "order 1"}), CodeNotFound, http.StatusNotFound, true},
+		{"plain error", stderrors.New("boom"), "", 0, false},
+		{"nil", nil, "", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appErr, ok := As(tt.err)
+			if ok != tt.wantOK {
+				t.Fatalf("As() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				if code := CodeOf(tt.err); code != CodeInternal {
+					t.Errorf("CodeOf() = %s, want %s", code, CodeInternal)
+				}
+				return
+			}
+			if appErr.Code != tt.wantCode || appErr.StatusCode() != tt.wantStatus {
+				t.Errorf("As() = %s %d, want %s %d", appErr.Code, appErr.StatusCode(), tt.wantCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"too many requests", TooManyRequests("slow down"), true},
+		{"timeout", Timeout("query timed out"), true},
+		{"unavailable", fmt.Errorf("publish: %w", Unavailable("nats down")), true},
+		{"not found", NotFound("order 1"), false},
+		{"marked retryable", Conflict("version changed").WithRetryable(true), true},
+		{"marked not retryable", Unavailable("maintenance").WithRetryable(false), false},
+		{"plain error", stderrors.New("boom"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildersLeaveSentinelsUnchanged(t *testing.T) {
+	err := ErrNotFound.WithDetail("id", "1").WithCause(stderrors.New("no rows")).WithRetryable(true)
+	if len(ErrNotFound.Details) != 0 || ErrNotFound.Cause != nil || ErrNotFound.Retryable {
+		t.Fatalf("ErrNotFound modified: %+v", ErrNotFound)
+	}
+	if err.Details["id"] != "1" || err.Cause == nil || !err.Retryable {
+		t.Errorf("builders not applied: %+v", err)
+	}
+}
@@ -5,6 +5,9 @@ import (
 	"net/http"
 )
 
+// The types below predate AppError and remain supported: they match the sentinel of their code
+// with errors.Is and render like an AppError with that code.
+
 // NotFoundError represents a 404 Not Found error.
 type NotFoundError struct {
 	Detail string
@@ -18,6 +21,16 @@ func (e NotFoundError) StatusCode() int {
 	return http.StatusNotFound // 404
 }
 
+// Is matches ErrNotFound.
+func (e NotFoundError) Is(target error) bool {
+	t, ok := target.(*AppError)
+	return ok && t.Code == CodeNotFound
+}
+
+func (e NotFoundError) appError() *AppError {
+	return New(CodeNotFound, e.Detail)
+}
+
 // BadRequestError represents a 400 Bad Request error.
 type BadRequestError struct {
 	Detail string
@@ -31,6 +44,16 @@ func (e BadRequestError) StatusCode() int {
 	return http.StatusBadRequest // 400
 }
 
+// Is matches ErrBadRequest.
+func (e BadRequestError) Is(target error) bool {
+	t, ok := target.(*AppError)
+	return ok && t.Code == CodeBadRequest
+}
+
+func (e BadRequestError) appError() *AppError {
+	return New(CodeBadRequest, e.Detail)
+}
+
 // UnauthorizedError represents a 401 Unauthorized error.
 type UnauthorizedError struct {
 	Detail string
@@ -44,6 +67,39 @@ func (e UnauthorizedError) StatusCode() int {
 	return http.StatusUnauthorized // 401
 }
 
+// Is matches ErrUnauthorized.
+func (e UnauthorizedError) Is(target error) bool {
+	t, ok := target.(*AppError)
+	return ok && t.Code == CodeUnauthorized
+}
+
+func (e UnauthorizedError) appError() *AppError {
+	return New(CodeUnauthorized, e.Detail)
+}
+
+// ForbiddenError represents a 403 Forbidden error.
+type ForbiddenError struct {
+	Detail string
+}
+
+func (e ForbiddenError) Error() string {
+	return fmt.Sprintf("forbidden: %s", e.Detail)
+}
+
+func (e ForbiddenError) StatusCode() int {
+	return http.StatusForbidden // 403
+}
+
+// Is matches ErrForbidden.
+func (e ForbiddenError) Is(target error) bool {
+	t, ok := target.(*AppError)
+	return ok && t.Code == CodeForbidden
+}
+
+func (e ForbiddenError) appError() *AppError {
+	return New(CodeForbidden, e.Detail)
+}
+
 // InternalServerError represents a 500 Internal Server Error.
 type InternalServerError struct {
 	Detail string
@@ -56,3 +112,13 @@ func (e InternalServerError) Error() string {
 func (e InternalServerError) StatusCode() int {
 	return http.StatusInternalServerError // 500
 }
+
+// Is matches ErrInternal.
+func (e InternalServerError) Is(target error) bool {
+	t, ok := target.(*AppError)
+	return ok && t.Code == CodeInternal
+}
+
+func (e InternalServerError) appError() *AppError {
+	return New(CodeInternal, e.Detail)
+}
@@ -0,0 +1,78 @@
+package errors
+
+import "net/http"
+
+// Wire is the transport-neutral form of an AppError exchanged between services, carried in
+// problem+json responses over HTTP and in headers over NATS. The cause stays in the service
+// that produced the error.
+type Wire struct {
+	Code      Code           `json:"code"`
+	Status    int            `json:"status"`
+	Message   string         `json:"message"`
+	Details   map[string]any `json:"details,omitempty"`
+	Retryable bool           `json:"retryable,omitempty"`
+}
+
+// ToWire returns the wire form of err. Errors without a code are sent as internal errors
+// without their message, which may leak implementation details.
+func ToWire(err error) Wire {
+	appErr, ok := As(err)
+	if !ok {
+		return Wire{Code: CodeInternal, Status: http.StatusInternalServerError, Message: ErrInternal.Message}
+	}
+	return Wire{
+		Code:      appErr.Code,
+		Status:    appErr.StatusCode(),
+		Message:   appErr.Message,
+		Details:   appErr.Details,
+		Retryable: appErr.Retryable,
+	}
+}
+
+// FromWire rebuilds the AppError received from another service. It matches the sentinels of its code,
+// so errors.Is(err, ErrNotFound) works on the caller's side.
+func FromWire(w Wire) *AppError {
+	if w.Code == "" {
+		// Sent by a service without codes, the status decides
+		appErr := New(CodeForStatus(w.Status), w.Message)
+		appErr.Status = w.Status
+		return appErr
+	}
+	if w.Status == 0 {
+		w.Status = w.Code.Status()
+	}
+	appErr := &AppError{
+		Code:      w.Code,
+		Status:    w.Status,
+		Message:   w.Message,
+		Retryable: w.Retryable,
+	}
+	if len(w.Details) > 0 {
+		appErr.Details = w.Details
+	}
+	return appErr
+}
+
+// CodeForStatus returns the code of an HTTP status, for errors received without one.
+func CodeForStatus(status int) Code {
+	switch status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return CodeBadRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusTooManyRequests:
+		return CodeTooManyRequests
+	case http.StatusGatewayTimeout, http.StatusRequestTimeout:
+		return CodeTimeout
+	case http.StatusServiceUnavailable, http.StatusBadGateway:
+		return CodeUnavailable
+	default:
+		return CodeInternal
+	}
+}
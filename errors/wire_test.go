@@ -0,0 +1,83 @@
+package errors
+
+import (
+	stderrors "errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestWireRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *AppError
+	}{
+		{"not found", NotFound("order 1 not found")},
+		{"validation with details", Validation("invalid order", map[string]string{"status": "is required"})},
+		{"retryable", Unavailable("database unavailable")},
+		{"custom status", &AppError{Code: CodeBadRequest, Status: http.StatusUnprocessableEntity, Message: "unprocessable"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FromWire(ToWire(tt.err))
+			if got.Code != tt.err.Code || got.StatusCode() != tt.err.StatusCode() || got.Message != tt.err.Message || got.Retryable != tt.err.Retryable {
+				t.Errorf("FromWire(ToWire()) = %+v, want %+v", got, tt.err)
+			}
+			if len(tt.err.Details) > 0 && !reflect.DeepEqual(got.Details, tt.err.Details) {
+				t.Errorf("details = %v, want %v", got.Details, tt.err.Details)
+			}
+			if !stderrors.Is(got, &AppError{Code: tt.err.Code}) {
+				t.Errorf("decoded error does not match the sentinel of %s", tt.err.Code)
+			}
+		})
+	}
+}
+
+func TestToWireHidesUncodedErrors(t *testing.T) {
+	wire := ToWire(stderrors.New("pq: password authentication failed for user admin"))
+	want := Wire{Code: CodeInternal, Status: http.StatusInternalServerError, Message: ErrInternal.Message}
+	if !reflect.DeepEqual(wire, want) {
+		t.Errorf("ToWire() = %+v, want %+v", wire, want)
+	}
+}
+
+func TestFromWireWithoutCode(t *testing.T) {
+	err := FromWire(Wire{Status: http.StatusBadGateway, Message: "upstream failed"})
+	if err.Code != CodeUnavailable || err.StatusCode() != http.StatusBadGateway {
+		t.Errorf("FromWire() = %s %d, want %s %d", err.Code, err.StatusCode(), CodeUnavailable, http.StatusBadGateway)
+	}
+	if !stderrors.Is(err, ErrUnavailable) {
+		t.Error("decoded error does not match ErrUnavailable")
+	}
+
+	if err := FromWire(Wire{Code: CodeNotFound}); err.StatusCode() != http.StatusNotFound {
+		t.Errorf("status without status = %d, want %d", err.StatusCode(), http.StatusNotFound)
+	}
+}
+
+func TestCodeForStatus(t *testing.T) {
+	tests := []struct {
+		status int
+		want   Code
+	}{
+		{http.StatusBadRequest, CodeBadRequest},
+		{http.StatusUnprocessableEntity, CodeBadRequest},
+		{http.StatusUnauthorized, CodeUnauthorized},
+		{http.StatusForbidden, CodeForbidden},
+		{http.StatusNotFound, CodeNotFound},
+		{http.StatusConflict, CodeConflict},
+		{http.StatusTooManyRequests, CodeTooManyRequests},
+		{http.StatusRequestTimeout, CodeTimeout},
+		{http.StatusGatewayTimeout, CodeTimeout},
+		{http.StatusBadGateway, CodeUnavailable},
+		{http.StatusServiceUnavailable, CodeUnavailable},
+		{http.StatusInternalServerError, CodeInternal},
+		{http.StatusTeapot, CodeInternal},
+		{0, CodeInternal},
+	}
+	for _, tt := range tests {
+		if got := CodeForStatus(tt.status); got != tt.want {
+			t.Errorf("CodeForStatus(%d) = %s, want %s", tt.status, got, tt.want)
+		}
+	}
+}
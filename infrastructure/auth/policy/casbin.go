@@ -8,6 +8,7 @@ import (
 	"github.com/casbin/casbin/v2/model"
 	xormadapter "github.com/casbin/xorm-adapter/v3"
 	"github.com/neodata-io/neodata-go/config"
+	"xorm.io/xorm"
 )
 
 func newModel() model.Model {
@@ -22,6 +23,13 @@ func newModel() model.Model {
 
 // InitializeCasbin creates and returns a new Casbin enforcer with a PostgreSQL adapter.
 func InitializeCasbin(cfg *config.AppConfig) (*casbin.Enforcer, error) {
+	enforcer, _, err := newEnforcer(cfg)
+	return enforcer, err
+}
+
+// newEnforcer is InitializeCasbin also returning the database engine of the adapter, which the
+// caller must close once the enforcer is no longer used.
+func newEnforcer(cfg *config.AppConfig) (*casbin.Enforcer, *xorm.Engine, error) {
 
 	databaseUrl := fmt.Sprintf(
 		"user=%s password=%s dbname=%s host=%s port=%d sslmode=disable",
@@ -33,21 +41,28 @@ func InitializeCasbin(cfg *config.AppConfig) (*casbin.Enforcer, error) {
 	)
 
 	// Connect to PostgreSQL as the adapter for Casbin
-	adapter, err := xormadapter.NewAdapter("postgres", databaseUrl)
+	engine, err := xorm.NewEngine("postgres", databaseUrl)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect Casbin database: %v", err)
+	}
+	adapter, err := xormadapter.NewAdapterByEngine(engine)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize Casbin adapter: %v", err)
+		engine.Close()
+		return nil, nil, fmt.Errorf("failed to initialize Casbin adapter: %v", err)
 	}
 
 	// Load Casbin model and policy from configuration file
 	enforcer, err := casbin.NewEnforcer(newModel(), adapter)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create Casbin enforcer: %v", err)
+		engine.Close()
+		return nil, nil, fmt.Errorf("failed to create Casbin enforcer: %v", err)
 	}
 
 	// Load policies from the database
 	if err := enforcer.LoadPolicy(); err != nil {
-		return nil, fmt.Errorf("failed to load Casbin policies: %v", err)
+		engine.Close()
+		return nil, nil, fmt.Errorf("failed to load Casbin policies: %v", err)
 	}
 
-	return enforcer, nil
+	return enforcer, engine, nil
 }
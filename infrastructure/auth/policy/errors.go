@@ -0,0 +1,32 @@
+package policy
+
+import (
+	stderrors "errors"
+
+	casbinerrors "github.com/casbin/casbin/v2/errors"
+	"github.com/neodata-io/neodata-go/errors"
+)
+
+// ErrClosed is the cause of the errors of the operations needing the adapter once the PolicyManager is closed.
+var ErrClosed = stderrors.New("policy manager is closed")
+
+// TranslateError converts Casbin enforcer errors into AppErrors wrapping them: unknown roles and
+// links become not found errors and invalid parameters bad requests. Other errors are returned unchanged.
+func TranslateError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := errors.As(err); ok {
+		return err
+	}
+
+	switch {
+	case stderrors.Is(err, casbinerrors.ErrNameNotFound), stderrors.Is(err, casbinerrors.ErrLinkNotFound):
+		return errors.NotFound("role not found").WithCause(err)
+	case stderrors.Is(err, casbinerrors.ErrInvalidFieldValuesParameter), stderrors.Is(err, casbinerrors.ErrDomainParameter),
+		stderrors.Is(err, casbinerrors.ErrUseDomainParameter):
+		return errors.BadRequest("invalid policy parameters").WithCause(err)
+	default:
+		return err
+	}
+}
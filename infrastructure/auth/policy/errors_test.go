@@ -0,0 +1,36 @@
+package policy
+
+import (
+	stderrors "errors"
+	"fmt"
+	"testing"
+
+	casbinerrors "github.com/casbin/casbin/v2/errors"
+	"github.com/neodata-io/neodata-go/errors"
+)
+
+func TestTranslateError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"unknown role", fmt.Errorf("delete role: %w", casbinerrors.ErrNameNotFound), errors.ErrNotFound},
+		{"unknown link", casbinerrors.ErrLinkNotFound, errors.ErrNotFound},
+		{"invalid parameters", casbinerrors.ErrInvalidFieldValuesParameter, errors.ErrBadRequest},
+		{"domain parameter", casbinerrors.ErrDomainParameter, errors.ErrBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			translated := TranslateError(tt.err)
+			if !stderrors.Is(translated, tt.want) || !stderrors.Is(translated, tt.err) {
+				t.Errorf("TranslateError() = %v, want %v wrapping %v", translated, tt.want, tt.err)
+			}
+		})
+	}
+
+	plain := stderrors.New("boom")
+	if got := TranslateError(plain); got != plain {
+		t.Errorf("TranslateError(%v) = %v, want it unchanged", plain, got)
+	}
+}
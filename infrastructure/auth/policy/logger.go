@@ -0,0 +1,66 @@
+package policy
+
+import (
+	"sync/atomic"
+
+	casbinlog "github.com/casbin/casbin/v2/log"
+	"github.com/neodata-io/neodata-go/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewCasbinLogger returns a Casbin logger logging through l. Models, policies, roles and enforce
+// decisions are logged at debug, errors at error. The caller of the entries is the Casbin code
+// rather than this adapter.
+func NewCasbinLogger(l logger.Logger) casbinlog.Logger {
+	return &casbinLogger{logger: logger.AddCallerSkip(l, 1)}
+}
+
+// casbinLogger implements the Casbin logger with a Logger.
+type casbinLogger struct {
+	logger  logger.Logger
+	enabled atomic.Bool
+}
+
+func (c *casbinLogger) EnableLog(enable bool) {
+	c.enabled.Store(enable)
+}
+
+// IsEnabled also requires debug logs, so Casbin skips building the entries that would be dropped.
+func (c *casbinLogger) IsEnabled() bool {
+	return c.enabled.Load() && logger.Enabled(c.logger, zapcore.DebugLevel)
+}
+
+func (c *casbinLogger) LogModel(model [][]string) {
+	if c.IsEnabled() {
+		c.logger.Debug("Casbin model", zap.Any("model", model))
+	}
+}
+
+func (c *casbinLogger) LogEnforce(matcher string, request []interface{}, result bool, explains [][]string) {
+	if c.IsEnabled() {
+		c.logger.Debug("Casbin enforce",
+			zap.String("matcher", matcher),
+			zap.Any("request", request),
+			zap.Bool("allowed", result),
+			zap.Any("explains", explains),
+		)
+	}
+}
+
+func (c *casbinLogger) LogRole(roles []string) {
+	if c.IsEnabled() {
+		c.logger.Debug("Casbin roles", zap.Strings("roles", roles))
+	}
+}
+
+func (c *casbinLogger) LogPolicy(policy map[string][][]string) {
+	if c.IsEnabled() {
+		c.logger.Debug("Casbin policy", zap.Any("policy", policy))
+	}
+}
+
+// LogError logs errors even when logging is disabled, as they are never noise.
+func (c *casbinLogger) LogError(err error, msg ...string) {
+	c.logger.Error("Casbin error", zap.Strings("messages", msg), zap.Error(err))
+}
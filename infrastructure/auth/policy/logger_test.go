@@ -0,0 +1,30 @@
+package policy
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/neodata-io/neodata-go/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestCasbinLoggerCaller(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	l := NewCasbinLogger(logger.Wrap(zap.New(core, zap.AddCaller())))
+	l.EnableLog(true)
+
+	l.LogRole([]string{"admin"})
+	l.LogError(fmt.Errorf("adapter failed"))
+
+	entries := logs.All()
+	if len(entries) != 2 {
+		t.Fatalf("logged %d entries, want 2", len(entries))
+	}
+	for _, entry := range entries {
+		if file := filepath.Base(entry.Caller.File); file != "logger_test.go" {
+			t.Errorf("caller of %q = %s, want logger_test.go", entry.Message, entry.Caller.File)
+		}
+	}
+}
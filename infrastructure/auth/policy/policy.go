@@ -2,23 +2,42 @@ package policy
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/casbin/casbin/v2"
 	"github.com/neodata-io/neodata-go/config"
+	"github.com/neodata-io/neodata-go/errors"
+	"github.com/neodata-io/neodata-go/logger"
+	"xorm.io/xorm"
 )
 
 type PolicyManager struct {
-	e *casbin.Enforcer
+	e      *casbin.Enforcer
+	engine *xorm.Engine
+
+	mu     sync.Mutex // Serializes reloads and Close
+	closed bool
 }
 
 func NewPolicyManager(cfg *config.AppConfig) (*PolicyManager, error) {
+	return NewPolicyManagerWithLogger(cfg, nil)
+}
+
+// NewPolicyManagerWithLogger is NewPolicyManager logging the Casbin models, policies and decisions
+// through l, see NewCasbinLogger.
+func NewPolicyManagerWithLogger(cfg *config.AppConfig, l logger.Logger) (*PolicyManager, error) {
 	// TODO: implement caching or singleton to prevent initiated multiple times
-	enforcer, err := InitializeCasbin(cfg)
+	enforcer, engine, err := newEnforcer(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("error adding policy: %v", err)
+		return nil, fmt.Errorf("error adding policy: %w", TranslateError(err))
+	}
+	if l != nil {
+		enforcer.SetLogger(NewCasbinLogger(l))
+		enforcer.EnableLog(true) // Entries are only built when l logs debug
 	}
 	return &PolicyManager{
-		e: enforcer,
+		e:      enforcer,
+		engine: engine,
 	}, nil
 }
 
@@ -27,7 +46,7 @@ func (pm *PolicyManager) AddPolicyForUser(user string, resource string, action s
 	// Add policy with the subject (user), object (resource), action, and effect (allow or deny)
 	_, err := pm.e.AddPolicy(user, resource, action, effect)
 	if err != nil {
-		return fmt.Errorf("error adding policy: %v", err)
+		return fmt.Errorf("error adding policy: %w", TranslateError(err))
 	}
 	return nil
 }
@@ -35,7 +54,7 @@ func (pm *PolicyManager) AddPolicyForUser(user string, resource string, action s
 func (pm *PolicyManager) AddPoliciesForUser(userID string, policies [][]string) error {
 	for _, policy := range policies {
 		if len(policy) != 3 {
-			return fmt.Errorf("invalid policy format: %v", policy)
+			return errors.BadRequest(fmt.Sprintf("invalid policy format: %v", policy))
 		}
 		err := pm.AddPolicyForUser(userID, policy[0], policy[1], policy[2])
 		if err != nil {
@@ -51,12 +70,12 @@ func (pm *PolicyManager) GetFilteredPolicy(index int, userID string) ([][]string
 	// Use Casbin's GetFilteredPolicy method to get policies for the given user.
 	policies, err := pm.e.GetFilteredPolicy(index, userID)
 	if err != nil {
-		return nil, fmt.Errorf("error retrieving permission for a specific user: %v", err)
+		return nil, fmt.Errorf("error retrieving permission for a specific user: %w", TranslateError(err))
 	}
 
 	// Check if any policies were returned.
 	if len(policies) == 0 {
-		return nil, fmt.Errorf("no policies found for user: %s", userID)
+		return nil, errors.NotFound(fmt.Sprintf("no policies found for user: %s", userID))
 	}
 
 	return policies, err
@@ -66,7 +85,7 @@ func (pm *PolicyManager) GetFilteredPolicy(index int, userID string) ([][]string
 func (pm *PolicyManager) HasPolicyForUser(userID string, resource string, action string, effect string) (bool, error) {
 	exists, err := pm.e.HasPolicy(userID, resource, action, effect)
 	if err != nil {
-		return false, fmt.Errorf("error checking policy permission for user %s: %v", userID, err)
+		return false, fmt.Errorf("error checking policy permission for user %s: %w", userID, TranslateError(err))
 	}
 	return exists, nil
 }
@@ -76,11 +95,11 @@ func (pm *PolicyManager) CanUserLogin(userID string) (bool, error) {
 	// Use Casbin's Enforce method to check if the user can execute the login action.
 	allowed, err := pm.e.Enforce(userID, "login", "execute")
 	if err != nil {
-		return false, fmt.Errorf("error checking login permission for user %s: %v", userID, err)
+		return false, fmt.Errorf("error checking login permission for user %s: %w", userID, TranslateError(err))
 	}
 
 	if !allowed {
-		return false, fmt.Errorf("user %s is not allowed to log in", userID)
+		return false, errors.Forbidden(fmt.Sprintf("user %s is not allowed to log in", userID))
 	}
 
 	return true, nil
@@ -91,11 +110,11 @@ func (pm *PolicyManager) RemovePolicyForUser(user string, resource string, actio
 	// Remove a specific policy that matches all four fields
 	removed, err := pm.e.RemovePolicy(user, resource, action, effect)
 	if err != nil {
-		return fmt.Errorf("error removing policy: %v", err)
+		return fmt.Errorf("error removing policy: %w", TranslateError(err))
 	}
 
 	if !removed {
-		return fmt.Errorf("policy not found for user: %s, resource: %s, action: %s", user, resource, action)
+		return errors.NotFound(fmt.Sprintf("policy not found for user: %s, resource: %s, action: %s", user, resource, action))
 	}
 
 	return nil
@@ -106,11 +125,11 @@ func (pm *PolicyManager) RemoveAllPoliciesForUser(user string) error {
 	// Remove all policies where the subject (user) matches
 	removed, err := pm.e.RemoveFilteredPolicy(0, user)
 	if err != nil {
-		return fmt.Errorf("error removing policies for user: %v", err)
+		return fmt.Errorf("error removing policies for user: %w", TranslateError(err))
 	}
 
 	if !removed {
-		return fmt.Errorf("no policies found for user: %s", user)
+		return errors.NotFound(fmt.Sprintf("no policies found for user: %s", user))
 	}
 
 	return nil
@@ -119,8 +138,11 @@ func (pm *PolicyManager) RemoveAllPoliciesForUser(user string) error {
 // AddMultiplePolicies adds multiple policies for multiple users in one call
 func (pm *PolicyManager) AddMultiplePolicies(policies [][]string) error {
 	ok, err := pm.e.AddPolicies(policies)
-	if err != nil || !ok {
-		return fmt.Errorf("error adding multiple policies: %v", err)
+	if err != nil {
+		return fmt.Errorf("error adding multiple policies: %w", TranslateError(err))
+	}
+	if !ok {
+		return errors.Conflict("error adding multiple policies: a policy already exists")
 	}
 	return nil
 }
@@ -128,8 +150,11 @@ func (pm *PolicyManager) AddMultiplePolicies(policies [][]string) error {
 // RemoveMultiplePolicies removes multiple policies in one call
 func (pm *PolicyManager) RemoveMultiplePolicies(policies [][]string) error {
 	ok, err := pm.e.RemovePolicies(policies)
-	if err != nil || !ok {
-		return fmt.Errorf("error removing multiple policies: %v", err)
+	if err != nil {
+		return fmt.Errorf("error removing multiple policies: %w", TranslateError(err))
+	}
+	if !ok {
+		return errors.NotFound("error removing multiple policies: a policy does not exist")
 	}
 	return nil
 }
@@ -138,7 +163,7 @@ func (pm *PolicyManager) RemoveMultiplePolicies(policies [][]string) error {
 func (pm *PolicyManager) CanUserPerformAction(user string, resource string, action string) (bool, error) {
 	allowed, err := pm.e.Enforce(user, resource, action)
 	if err != nil {
-		return false, fmt.Errorf("error enforcing policy: %v", err)
+		return false, fmt.Errorf("error enforcing policy: %w", TranslateError(err))
 	}
 	return allowed, nil
 }
@@ -148,9 +173,32 @@ func (pm *PolicyManager) ResetPolicies() {
 	pm.e.ClearPolicy()
 }
 
+// Close detaches the adapter from the enforcer and closes its database engine.
+// Policies already loaded stay enforceable, but they can no longer be reloaded.
+func (pm *PolicyManager) Close() error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if pm.closed {
+		return nil
+	}
+	pm.e.SetAdapter(nil)
+	pm.closed = true
+	if err := pm.engine.Close(); err != nil {
+		return fmt.Errorf("failed to close policy database: %w", err)
+	}
+	return nil
+}
+
 func (pm *PolicyManager) ReloadPolicies() error {
+	pm.mu.Lock()
+	defer pm.mu.Unlock()
+
+	if pm.closed {
+		return errors.Unavailable("failed to reload policies").WithCause(ErrClosed)
+	}
 	if err := pm.e.LoadPolicy(); err != nil {
-		return fmt.Errorf("failed to reload policies: %w", err)
+		return fmt.Errorf("failed to reload policies: %w", TranslateError(err))
 	}
 	return nil
 }
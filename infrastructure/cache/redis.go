@@ -4,6 +4,7 @@ package cache
 import (
 	"context"
 
+	"github.com/neodata-io/neodata-go/config"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -16,6 +17,12 @@ func NewRedisCache() *RedisCache {
 	return &RedisCache{client: client}
 }
 
+// NewRedisCacheFromConfig creates a cache connected to the configured Redis address.
+func NewRedisCacheFromConfig(cfg *config.AppConfig) *RedisCache {
+	client := redis.NewClient(&redis.Options{Addr: cfg.Redis.Address})
+	return &RedisCache{client: client}
+}
+
 func (c *RedisCache) Get(key string) (string, error) {
 	return c.client.Get(context.Background(), key).Result()
 }
@@ -23,3 +30,13 @@ func (c *RedisCache) Get(key string) (string, error) {
 func (c *RedisCache) Set(key string, value string) error {
 	return c.client.Set(context.Background(), key, value, 0).Err()
 }
+
+// Ping checks that Redis is reachable.
+func (c *RedisCache) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
+}
+
+// Close closes the underlying Redis client and its connection pool.
+func (c *RedisCache) Close() error {
+	return c.client.Close()
+}
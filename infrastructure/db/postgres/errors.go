@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	stderrors "errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/neodata-io/neodata-go/errors"
+)
+
+// PostgreSQL error codes translated by TranslateError, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pgUniqueViolation      = "23505"
+	pgForeignKeyViolation  = "23503"
+	pgNotNullViolation     = "23502"
+	pgCheckViolation       = "23514"
+	pgStringTooLong        = "22001"
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+	pgQueryCanceled        = "57014"
+	pgTooManyConnections   = "53300"
+	pgConnectionException  = "08" // Class of the connection errors
+)
+
+// TranslateError converts pgx errors into AppErrors wrapping them, so handlers respond with the
+// right status: unique violations become conflicts, foreign key violations bad requests and
+// serialization failures retryable conflicts. Other errors are returned unchanged.
+//
+//	if _, err := pool.Exec(ctx, insertUser, email); err != nil {
+//		return postgres.TranslateError(err)
+//	}
+func TranslateError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := errors.As(err); ok {
+		return err
+	}
+
+	if stderrors.Is(err, pgx.ErrNoRows) {
+		return errors.NotFound("record not found").WithCause(err)
+	}
+	if pgconn.Timeout(err) {
+		return errors.Timeout("database query timed out").WithCause(err)
+	}
+
+	var pgErr *pgconn.PgError
+	if !stderrors.As(err, &pgErr) {
+		return err
+	}
+
+	var appErr *errors.AppError
+	switch {
+	case pgErr.Code == pgUniqueViolation:
+		appErr = errors.Conflict("record already exists")
+	case pgErr.Code == pgForeignKeyViolation:
+		appErr = errors.BadRequest("referenced record does not exist")
+	case pgErr.Code == pgNotNullViolation, pgErr.Code == pgCheckViolation, pgErr.Code == pgStringTooLong:
+		appErr = errors.BadRequest("invalid value")
+	case pgErr.Code == pgSerializationFailure, pgErr.Code == pgDeadlockDetected:
+		appErr = errors.Conflict("concurrent update, retry the request").WithRetryable(true)
+	case pgErr.Code == pgQueryCanceled:
+		appErr = errors.Timeout("database query canceled")
+	case pgErr.Code == pgTooManyConnections, len(pgErr.Code) == 5 && pgErr.Code[:2] == pgConnectionException:
+		appErr = errors.Unavailable("database unavailable")
+	default:
+		return err
+	}
+
+	// The names of the violated objects help clients, the values in pgErr.Detail may be sensitive
+	for key, value := range map[string]string{"constraint": pgErr.ConstraintName, "table": pgErr.TableName, "column": pgErr.ColumnName} {
+		if value != "" {
+			appErr = appErr.WithDetail(key, value)
+		}
+	}
+	return appErr.WithCause(err)
+}
@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/neodata-io/neodata-go/errors"
+)
+
+func TestTranslateError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		code      errors.Code
+		retryable bool
+	}{
+		{"no rows", fmt.Errorf("get order: %w", pgx.ErrNoRows), errors.CodeNotFound, false},
+		{"unique violation", &pgconn.PgError{Code: pgUniqueViolation}, errors.CodeConflict, false},
+		{"foreign key violation", &pgconn.PgError{Code: pgForeignKeyViolation}, errors.CodeBadRequest, false},
+		{"not null violation", &pgconn.PgError{Code: pgNotNullViolation}, errors.CodeBadRequest, false},
+		{"serialization failure", &pgconn.PgError{Code: pgSerializationFailure}, errors.CodeConflict, true},
+		{"deadlock", &pgconn.PgError{Code: pgDeadlockDetected}, errors.CodeConflict, true},
+		{"query canceled", &pgconn.PgError{Code: pgQueryCanceled}, errors.CodeTimeout, true},
+		{"too many connections", &pgconn.PgError{Code: pgTooManyConnections}, errors.CodeUnavailable, true},
+		{"connection failure", &pgconn.PgError{Code: "08006"}, errors.CodeUnavailable, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			translated := TranslateError(tt.err)
+			appErr, ok := errors.As(translated)
+			if !ok {
+				t.Fatalf("TranslateError() = %v, want an AppError", translated)
+			}
+			if appErr.Code != tt.code || appErr.Retryable != tt.retryable {
+				t.Errorf("TranslateError() = %s retryable %v, want %s retryable %v", appErr.Code, appErr.Retryable, tt.code, tt.retryable)
+			}
+			if !stderrors.Is(translated, tt.err) {
+				t.Error("translated error does not wrap the pgx error")
+			}
+		})
+	}
+}
+
+func TestTranslateErrorDetails(t *testing.T) {
+	err := &pgconn.PgError{Code: pgUniqueViolation, ConstraintName: "users_email_key", TableName: "users", Detail: "Key (email)=(jane@example.com) already exists."}
+	appErr, _ := errors.As(TranslateError(err))
+	if appErr.StatusCode() != http.StatusConflict || appErr.Details["constraint"] != "users_email_key" || appErr.Details["table"] != "users" {
+		t.Errorf("TranslateError() = %+v, want a conflict naming the constraint and table", appErr)
+	}
+	if _, ok := appErr.Details["column"]; ok {
+		t.Error("empty column reported")
+	}
+	for _, value := range appErr.Details {
+		if value == err.Detail {
+			t.Error("detail of the violated row reported")
+		}
+	}
+}
+
+func TestTranslateErrorUnchanged(t *testing.T) {
+	plain := stderrors.New("boom")
+	appErr := errors.NotFound("order not found")
+	unknown := &pgconn.PgError{Code: "42P01"} // Undefined table, a bug rather than a client error
+
+	for _, err := range []error{nil, plain, appErr, unknown} {
+		if got := TranslateError(err); got != err {
+			t.Errorf("TranslateError(%v) = %v, want it unchanged", err, got)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"sort"
+
+	"github.com/jackc/pgx/v5/tracelog"
+	"github.com/neodata-io/neodata-go/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewTraceLogger returns a pgx tracelog.Logger logging through l. Queries, logged by pgx at info,
+// are logged at debug; the correlation and trace IDs of the query context are added to every entry.
+// The caller of the entries is the pgx tracer rather than this adapter.
+func NewTraceLogger(l logger.Logger) tracelog.Logger {
+	return &traceLogger{logger: logger.AddCallerSkip(l, 1)}
+}
+
+// NewQueryTracer returns the tracer of a pool logging queries and errors through l, see NewTraceLogger.
+func NewQueryTracer(l logger.Logger) *tracelog.TraceLog {
+	return &tracelog.TraceLog{
+		Logger:   NewTraceLogger(l),
+		LogLevel: tracelog.LogLevelInfo,
+	}
+}
+
+// traceLogger implements tracelog.Logger with a Logger.
+type traceLogger struct {
+	logger logger.Logger
+}
+
+func (t *traceLogger) Log(ctx context.Context, level tracelog.LogLevel, msg string, data map[string]any) {
+	zapLevel := traceLevel(level)
+	if level == tracelog.LogLevelNone || !logger.Enabled(t.logger, zapLevel) {
+		return
+	}
+
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fields := make([]zap.Field, 0, len(data)+3)
+	for _, key := range keys {
+		if err, ok := data[key].(error); ok {
+			fields = append(fields, zap.NamedError(key, err))
+			continue
+		}
+		fields = append(fields, zap.Any(key, data[key]))
+	}
+	if correlationID := logger.CorrelationID(ctx); correlationID != "" {
+		fields = append(fields, zap.String("correlation_id", correlationID))
+	}
+	fields = append(fields, logger.TraceFields(ctx)...)
+
+	switch zapLevel {
+	case zapcore.DebugLevel:
+		t.logger.Debug(msg, fields...)
+	case zapcore.WarnLevel:
+		t.logger.Warn(msg, fields...)
+	default:
+		t.logger.Error(msg, fields...)
+	}
+}
+
+// traceLevel maps a pgx level to the zap level logging it.
+func traceLevel(level tracelog.LogLevel) zapcore.Level {
+	switch level {
+	case tracelog.LogLevelWarn:
+		return zapcore.WarnLevel
+	case tracelog.LogLevelError:
+		return zapcore.ErrorLevel
+	default:
+		return zapcore.DebugLevel
+	}
+}
@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgx/v5/tracelog"
+	"github.com/neodata-io/neodata-go/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestTraceLoggerCaller(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	l := NewTraceLogger(logger.Wrap(zap.New(core, zap.AddCaller())))
+
+	ctx := logger.WithCorrelationID(context.Background(), "abc")
+	l.Log(ctx, tracelog.LogLevelInfo, "Query", map[string]any{"sql": "select 1"})
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("logged %d entries, want 1", len(entries))
+	}
+	if entries[0].Level != zap.DebugLevel {
+		t.Errorf("level = %s, want debug", entries[0].Level)
+	}
+	if file := filepath.Base(entries[0].Caller.File); file != "logger_test.go" {
+		t.Errorf("caller = %s, want logger_test.go", entries[0].Caller.File)
+	}
+	if correlationID := entries[0].ContextMap()["correlation_id"]; correlationID != "abc" {
+		t.Errorf("correlation_id = %v, want abc", correlationID)
+	}
+}
@@ -8,22 +8,19 @@ import (
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/neodata-io/neodata-go/config"
+	"github.com/neodata-io/neodata-go/logger"
 )
 
 // NewPool initializes a PostgreSQL connection pool with given parameters.
 func NewPool(ctx context.Context, cfg *config.AppConfig) (*pgxpool.Pool, error) {
-	// Validate mandatory environment variables
-	if cfg.Database.User == "" {
-		return nil, fmt.Errorf("missing database user in configuration")
-	}
-	if cfg.Database.Host == "" {
-		return nil, fmt.Errorf("missing database host in configuration")
-	}
-	if cfg.Database.Port == 0 {
-		return nil, fmt.Errorf("missing database port in configuration")
-	}
-	if cfg.Database.Name == "" {
-		return nil, fmt.Errorf("missing database name in configuration")
+	return NewPoolWithLogger(ctx, cfg, nil)
+}
+
+// NewPoolWithLogger is NewPool logging the queries and errors of the pool through l, see NewQueryTracer.
+func NewPoolWithLogger(ctx context.Context, cfg *config.AppConfig, l logger.Logger) (*pgxpool.Pool, error) {
+	// Validate mandatory configuration, the pool needs nothing but the database section
+	if err := config.ValidateSections(cfg, config.SectionDatabase); err != nil {
+		return nil, err
 	}
 
 	// Construct the database URL
@@ -39,6 +36,9 @@ func NewPool(ctx context.Context, cfg *config.AppConfig) (*pgxpool.Pool, error)
 	if err != nil {
 		return nil, err
 	}
+	if l != nil {
+		config.ConnConfig.Tracer = NewQueryTracer(l)
+	}
 
 	// Connect to the PostgreSQL database using pgxpool
 	dbPool, err := pgxpool.NewWithConfig(ctx, config)
@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/neodata-io/neodata-go/config"
+)
+
+func TestNewPoolValidatesOnlyDatabase(t *testing.T) {
+	// A client outside of a neodata App has no app or logger section
+	cfg := &config.AppConfig{}
+	cfg.Database.Type, cfg.Database.Host, cfg.Database.Port = "postgres", "localhost", 5432
+	cfg.Database.Name, cfg.Database.User = "orders", "orders"
+
+	// The pool connects lazily, nothing is dialed
+	pool, err := NewPool(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("NewPool() error = %v", err)
+	}
+	pool.Close()
+
+	cfg.Database.Host = ""
+	_, err = NewPool(context.Background(), cfg)
+	var validationErr *config.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("NewPool() error = %v, want a *config.ValidationError", err)
+	}
+	if len(validationErr.Fields) != 1 || validationErr.Fields[0].Key != "database.host" {
+		t.Errorf("invalid keys = %+v, want database.host only", validationErr.Fields)
+	}
+	if strings.Contains(err.Error(), "app.") || strings.Contains(err.Error(), "logger.") {
+		t.Errorf("NewPool() error = %v, reports sections it does not use", err)
+	}
+}
@@ -0,0 +1,107 @@
+package messaging
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/nats-io/nats.go"
+	"github.com/neodata-io/neodata-go/errors"
+)
+
+// Headers carrying an error across NATS. The Nats-Service-Error headers follow the convention of
+// the NATS micro framework, so services built with it understand the errors too.
+const (
+	HeaderErrorCode      = "Neodata-Error-Code"
+	HeaderErrorStatus    = "Neodata-Error-Status"
+	HeaderErrorMessage   = "Neodata-Error-Message" // Percent-encoded
+	HeaderErrorRetryable = "Neodata-Error-Retryable"
+	HeaderErrorDetails   = "Neodata-Error-Details" // Percent-encoded JSON object
+	HeaderServiceError   = "Nats-Service-Error"    // Control characters replaced by spaces
+	HeaderServiceCode    = "Nats-Service-Error-Code"
+)
+
+// EncodeErrorHeaders writes err into the headers of a reply, see errors.ToWire. The message and the
+// details are percent-encoded, so a CR or LF in them cannot end the header and inject others.
+func EncodeErrorHeaders(err error, header nats.Header) {
+	wire := errors.ToWire(err)
+	header.Set(HeaderErrorCode, stripControl(string(wire.Code)))
+	header.Set(HeaderErrorStatus, strconv.Itoa(wire.Status))
+	header.Set(HeaderErrorMessage, url.PathEscape(wire.Message))
+	header.Set(HeaderErrorRetryable, strconv.FormatBool(wire.Retryable))
+	if len(wire.Details) > 0 {
+		if details, err := json.Marshal(wire.Details); err == nil {
+			header.Set(HeaderErrorDetails, url.PathEscape(string(details)))
+		}
+	}
+	// Plain text, as services built with the NATS micro framework expect
+	header.Set(HeaderServiceError, stripControl(wire.Message))
+	header.Set(HeaderServiceCode, strconv.Itoa(wire.Status))
+}
+
+// DecodeErrorHeaders returns the error carried by the headers of a reply, nil when there is none.
+func DecodeErrorHeaders(header nats.Header) error {
+	code := header.Get(HeaderErrorCode)
+	status, _ := strconv.Atoi(header.Get(HeaderErrorStatus))
+	if code == "" {
+		// Sent by a service following the micro convention only
+		serviceCode := header.Get(HeaderServiceCode)
+		if serviceCode == "" {
+			return nil
+		}
+		status, _ = strconv.Atoi(serviceCode)
+		return errors.FromWire(errors.Wire{Status: status, Message: header.Get(HeaderServiceError)})
+	}
+
+	wire := errors.Wire{
+		Code:    errors.Code(code),
+		Status:  status,
+		Message: unescapeHeader(header.Get(HeaderErrorMessage)),
+	}
+	wire.Retryable, _ = strconv.ParseBool(header.Get(HeaderErrorRetryable))
+	if details := header.Get(HeaderErrorDetails); details != "" {
+		_ = json.Unmarshal([]byte(unescapeHeader(details)), &wire.Details)
+	}
+	return errors.FromWire(wire)
+}
+
+// stripControl replaces the control characters of s, CR and LF included, by spaces.
+func stripControl(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+// unescapeHeader decodes a percent-encoded header value. Values that are not valid percent-encoding,
+// as sent by older versions, are returned unchanged.
+func unescapeHeader(value string) string {
+	if unescaped, err := url.PathUnescape(value); err == nil {
+		return unescaped
+	}
+	return value
+}
+
+// RespondError replies to a request with err encoded in the headers and an empty body.
+func RespondError(msg *nats.Msg, err error) error {
+	reply := nats.NewMsg(msg.Reply)
+	EncodeErrorHeaders(err, reply.Header)
+	return msg.RespondMsg(reply)
+}
+
+// ReplyError returns the error of a reply received with Request, nil for successful replies.
+//
+//	reply, err := nc.Request("orders.get", payload, time.Second)
+//	if err == nil {
+//		err = messaging.ReplyError(reply)
+//	}
+func ReplyError(reply *nats.Msg) error {
+	if reply == nil || reply.Header == nil {
+		return nil
+	}
+	return DecodeErrorHeaders(reply.Header)
+}
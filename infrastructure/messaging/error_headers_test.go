@@ -0,0 +1,95 @@
+package messaging
+
+import (
+	stderrors "errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/neodata-io/neodata-go/errors"
+)
+
+func TestErrorHeadersRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want *errors.AppError
+	}{
+		{"app error", errors.NotFound("order 1 not found"), errors.NotFound("order 1 not found")},
+		{"details", errors.Validation("invalid order", map[string]string{"status": "is required"}), errors.Validation("invalid order", map[string]string{"status": "is required"})},
+		{"retryable", errors.Unavailable("database unavailable"), errors.Unavailable("database unavailable")},
+		{"uncoded error", stderrors.New("pq: connection refused"), errors.Internal("internal error")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := nats.Header{}
+			EncodeErrorHeaders(tt.err, header)
+
+			got, ok := errors.As(DecodeErrorHeaders(header))
+			if !ok {
+				t.Fatal("DecodeErrorHeaders() returned no AppError")
+			}
+			if got.Code != tt.want.Code || got.StatusCode() != tt.want.StatusCode() || got.Message != tt.want.Message || got.Retryable != tt.want.Retryable {
+				t.Errorf("decoded = %+v, want %+v", got, tt.want)
+			}
+			for key, value := range tt.want.Details {
+				if got.Details[key] != value {
+					t.Errorf("detail %s = %v, want %v", key, got.Details[key], value)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeMicroErrorHeaders(t *testing.T) {
+	header := nats.Header{}
+	header.Set(HeaderServiceError, "order not found")
+	header.Set(HeaderServiceCode, "404")
+
+	err := DecodeErrorHeaders(header)
+	if !stderrors.Is(err, errors.ErrNotFound) {
+		t.Fatalf("DecodeErrorHeaders() = %v, want a not found error", err)
+	}
+	if appErr, _ := errors.As(err); appErr.Message != "order not found" || appErr.StatusCode() != http.StatusNotFound {
+		t.Errorf("decoded = %+v", appErr)
+	}
+}
+
+func TestReplyErrorWithoutError(t *testing.T) {
+	if err := ReplyError(nil); err != nil {
+		t.Errorf("ReplyError(nil) = %v, want nil", err)
+	}
+	if err := ReplyError(&nats.Msg{Header: nats.Header{"Content-Type": {"application/json"}}}); err != nil {
+		t.Errorf("ReplyError() of a successful reply = %v, want nil", err)
+	}
+}
+
+func TestErrorHeadersEscapeControlCharacters(t *testing.T) {
+	message := "order not found\r\nNeodata-Error-Code: internal\x00"
+	header := nats.Header{}
+	EncodeErrorHeaders(errors.NotFound(message).WithDetail("id", "1\r\n2"), header)
+
+	for key, values := range header {
+		for _, value := range values {
+			if strings.ContainsAny(value, "\r\n\x00") {
+				t.Errorf("header %s = %q contains control characters", key, value)
+			}
+		}
+	}
+
+	got, _ := errors.As(DecodeErrorHeaders(header))
+	if got.Code != errors.CodeNotFound || got.Message != message || got.Details["id"] != "1\r\n2" {
+		t.Errorf("decoded = %+v, want the original message and details", got)
+	}
+}
+
+func TestDecodeUnescapedErrorMessage(t *testing.T) {
+	header := nats.Header{}
+	header.Set(HeaderErrorCode, string(errors.CodeConflict))
+	header.Set(HeaderErrorMessage, "100% booked")
+
+	if got, _ := errors.As(DecodeErrorHeaders(header)); got.Message != "100% booked" {
+		t.Errorf("decoded message = %q, want it unchanged", got.Message)
+	}
+}
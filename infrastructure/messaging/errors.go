@@ -0,0 +1,62 @@
+package messaging
+
+import (
+	stderrors "errors"
+	"net/http"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/neodata-io/neodata-go/errors"
+)
+
+// TranslateError converts NATS and JetStream errors into AppErrors wrapping them: missing responders
+// and closed connections become retryable unavailable errors, timeouts retryable timeouts and
+// JetStream API errors take the code of their status. Other errors are returned unchanged.
+func TranslateError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := errors.As(err); ok {
+		return err
+	}
+
+	switch {
+	case stderrors.Is(err, nats.ErrNoResponders), stderrors.Is(err, jetstream.ErrNoStreamResponse):
+		return errors.Unavailable("no responders for the message").WithCause(err)
+	case stderrors.Is(err, nats.ErrTimeout):
+		return errors.Timeout("messaging request timed out").WithCause(err)
+	case stderrors.Is(err, nats.ErrConnectionClosed), stderrors.Is(err, nats.ErrConnectionDraining),
+		stderrors.Is(err, nats.ErrDisconnected):
+		return errors.Unavailable("messaging connection unavailable").WithCause(err)
+	}
+
+	var jsErr jetstream.JetStreamError
+	if !stderrors.As(err, &jsErr) || jsErr.APIError() == nil {
+		return err
+	}
+	apiErr := jsErr.APIError()
+	if apiErr.ErrorCode == jetstream.JSErrCodeStreamWrongLastSequence {
+		return errors.Conflict(apiErr.Description).WithCause(err)
+	}
+	switch apiErr.Code {
+	case http.StatusBadRequest:
+		return errors.BadRequest(apiErr.Description).WithCause(err)
+	case http.StatusNotFound:
+		return errors.NotFound(apiErr.Description).WithCause(err)
+	case http.StatusServiceUnavailable:
+		return errors.Unavailable(apiErr.Description).WithCause(err)
+	default:
+		return errors.Internal(apiErr.Description).WithCause(err)
+	}
+}
+
+// shouldRedeliver reports whether a message whose handler failed with err is worth redelivering.
+// Non-retryable client errors, such as a bad request or a conflict, would fail again; other errors
+// may be transient.
+func shouldRedeliver(err error) bool {
+	appErr, ok := errors.As(TranslateError(err))
+	if !ok || appErr.Retryable {
+		return true
+	}
+	return appErr.StatusCode() >= http.StatusInternalServerError
+}
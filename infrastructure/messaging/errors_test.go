@@ -0,0 +1,69 @@
+package messaging
+
+import (
+	stderrors "errors"
+	"fmt"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/neodata-io/neodata-go/errors"
+)
+
+func TestTranslateError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		code      errors.Code
+		retryable bool
+	}{
+		{"no responders", fmt.Errorf("request: %w", nats.ErrNoResponders), errors.CodeUnavailable, true},
+		{"timeout", nats.ErrTimeout, errors.CodeTimeout, true},
+		{"connection closed", nats.ErrConnectionClosed, errors.CodeUnavailable, true},
+		{"stream not found", jetstream.ErrStreamNotFound, errors.CodeNotFound, false},
+		{"wrong last sequence", jetstream.ErrKeyExists, errors.CodeConflict, false},
+		{"bad request", &jetstream.APIError{Code: 400, Description: "invalid subject"}, errors.CodeBadRequest, false},
+		{"server error", &jetstream.APIError{Code: 500, Description: "insufficient resources"}, errors.CodeInternal, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			translated := TranslateError(tt.err)
+			appErr, ok := errors.As(translated)
+			if !ok {
+				t.Fatalf("TranslateError() = %v, want an AppError", translated)
+			}
+			if appErr.Code != tt.code || appErr.Retryable != tt.retryable {
+				t.Errorf("TranslateError() = %s retryable %v, want %s retryable %v", appErr.Code, appErr.Retryable, tt.code, tt.retryable)
+			}
+			if !stderrors.Is(translated, tt.err) {
+				t.Error("translated error does not wrap the NATS error")
+			}
+		})
+	}
+
+	plain := stderrors.New("boom")
+	if got := TranslateError(plain); got != plain {
+		t.Errorf("TranslateError(%v) = %v, want it unchanged", plain, got)
+	}
+}
+
+func TestShouldRedeliver(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"plain error", stderrors.New("boom"), true},
+		{"retryable", errors.Unavailable("database unavailable"), true},
+		{"internal", errors.Internal("bug"), true},
+		{"bad request", errors.BadRequest("invalid payload"), false},
+		{"conflict", errors.Conflict("duplicate order"), false},
+		{"retryable conflict", errors.Conflict("concurrent update").WithRetryable(true), true},
+		{"translated timeout", nats.ErrTimeout, true},
+	}
+	for _, tt := range tests {
+		if got := shouldRedeliver(tt.err); got != tt.want {
+			t.Errorf("shouldRedeliver(%s) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
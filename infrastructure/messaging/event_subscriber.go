@@ -1,30 +1,130 @@
 package messaging
 
-/* // EventHandler defines the interface for handling events
-type EventHandler func(msg *nats.Msg) error
+import (
+	"context"
+	"fmt"
+	"sync"
 
-// EventSubscriber handles subscribing to events from NATS JetStream
-type EventSubscriber struct {
-	jetStream nats.JetStreamContext
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/neodata-io/neodata-go/logger"
+	"go.uber.org/zap"
+)
+
+// EventHandler processes a single message. ctx carries a logger with the subject, stream, consumer
+// and the correlation and trace IDs sent by the publisher, see logger.FromContext. Returning an error naks the message so it is redelivered,
+// unless it is a non-retryable client error of the errors package, such as errors.BadRequest, which
+// terminates the message.
+type EventHandler func(ctx context.Context, msg jetstream.Msg) error
+
+// Subscriber consumes messages from JetStream durable consumers and keeps track of
+// the handlers that are still running so they can be drained on shutdown.
+type Subscriber struct {
+	jetStream jetstream.JetStream
+	logger    *zap.Logger
+
+	mu       sync.Mutex
+	consumes []jetstream.ConsumeContext
+	inflight sync.WaitGroup
+	draining bool
 }
 
-// NewEventSubscriber creates a new instance of EventSubscriber
-func NewEventSubscriber(client *NATSClient) *EventSubscriber {
-	return &EventSubscriber{jetStream: client.JetStream}
+// NewSubscriber creates a new instance of Subscriber
+func NewSubscriber(client *NATSClient, logger *zap.Logger) *Subscriber {
+	return &Subscriber{
+		jetStream: client.js,
+		logger:    logger,
+	}
 }
 
-// Subscribe subscribes to a specific subject and processes events with the provided handler
-func (s *EventSubscriber) Subscribe(subject, durableName string, handler EventHandler) error {
-	_, err := s.jetStream.Subscribe(subject, func(msg *nats.Msg) {
-		if err := handler(msg); err != nil {
-			log.Printf("Failed to process message: %v", err)
+// Subscribe starts consuming messages from an existing durable consumer of a stream
+func (s *Subscriber) Subscribe(ctx context.Context, stream, consumer string, handler EventHandler) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.draining {
+		return fmt.Errorf("subscriber is draining, cannot subscribe to %s/%s", stream, consumer)
+	}
+
+	cons, err := s.jetStream.Consumer(ctx, stream, consumer)
+	if err != nil {
+		return fmt.Errorf("failed to get consumer %s on stream %s: %w", consumer, stream, err)
+	}
+
+	cc, err := cons.Consume(func(msg jetstream.Msg) {
+		s.inflight.Add(1)
+		defer s.inflight.Done()
+
+		msgCtx := s.messageContext(stream, consumer, msg)
+		msgLogger := logger.FromContext(msgCtx)
+
+		if err := handler(msgCtx, msg); err != nil {
+			msgLogger.Error("Failed to process message", zap.Error(err))
+			if !shouldRedeliver(err) {
+				// The message would fail again, stop its redelivery
+				if termErr := msg.Term(); termErr != nil {
+					msgLogger.Error("Failed to terminate message", zap.Error(termErr))
+				}
+				return
+			}
+			if nakErr := msg.Nak(); nakErr != nil {
+				msgLogger.Error("Failed to nak message", zap.Error(nakErr))
+			}
+			return
+		}
+		if ackErr := msg.Ack(); ackErr != nil {
+			msgLogger.Error("Failed to ack message", zap.Error(ackErr))
 		}
-	}, nats.Durable(durableName), nats.ManualAck())
+	})
 	if err != nil {
-		log.Printf("Failed to subscribe to subject %s: %v", subject, err)
-		return err
+		return fmt.Errorf("failed to consume from %s on stream %s: %w", consumer, stream, err)
 	}
-	log.Printf("Subscribed to subject: %s", subject)
+
+	s.consumes = append(s.consumes, cc)
+	s.logger.Info("Subscribed to consumer", zap.String("stream", stream), zap.String("consumer", consumer))
 	return nil
 }
-*/
+
+// messageContext returns the context of a message handler, carrying the correlation ID and trace
+// context of the message and a logger with the message fields.
+func (s *Subscriber) messageContext(stream, consumer string, msg jetstream.Msg) context.Context {
+	ctx := extractContext(context.Background(), msg.Headers())
+	return logger.WithContext(ctx, s.logger.With(
+		zap.String("subject", msg.Subject()),
+		zap.String("stream", stream),
+		zap.String("consumer", consumer),
+		zap.String("correlation_id", logger.CorrelationID(ctx)),
+	))
+}
+
+// Drain stops fetching new messages and waits for buffered messages and running handlers
+// to finish. It returns an error if ctx ends first.
+func (s *Subscriber) Drain(ctx context.Context) error {
+	s.mu.Lock()
+	s.draining = true
+	consumes := s.consumes
+	s.consumes = nil
+	s.mu.Unlock()
+
+	for _, cc := range consumes {
+		cc.Drain()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		for _, cc := range consumes {
+			<-cc.Closed()
+		}
+		s.inflight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		for _, cc := range consumes {
+			cc.Stop()
+		}
+		return fmt.Errorf("message handlers did not finish: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,31 @@
+package messaging
+
+import (
+	"github.com/nats-io/nats.go"
+	"github.com/neodata-io/neodata-go/logger"
+	"go.uber.org/zap"
+)
+
+// ConnectionHandlers returns the NATS options logging the asynchronous errors, disconnects,
+// reconnects and closing of a connection through l.
+func ConnectionHandlers(l logger.Logger) []nats.Option {
+	return []nats.Option{
+		nats.ErrorHandler(func(_ *nats.Conn, sub *nats.Subscription, err error) {
+			fields := []zap.Field{zap.Error(err)}
+			if sub != nil {
+				fields = append(fields, zap.String("subject", sub.Subject))
+			}
+			l.Error("NATS asynchronous error", fields...)
+		}),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			// err is nil when the connection is closed on purpose
+			l.Warn("NATS disconnected", zap.Error(err))
+		}),
+		nats.ReconnectHandler(func(nc *nats.Conn) {
+			l.Info("NATS reconnected", zap.String("url", nc.ConnectedUrlRedacted()))
+		}),
+		nats.ClosedHandler(func(nc *nats.Conn) {
+			l.Info("NATS connection closed", zap.Error(nc.LastError()))
+		}),
+	}
+}
@@ -3,26 +3,33 @@ package messaging
 import (
 	"context"
 	"fmt"
-	"log"
 	"time"
 
 	"github.com/nats-io/nats.go"
 	"github.com/nats-io/nats.go/jetstream"
 	"github.com/neodata-io/neodata-go/config"
+	"github.com/neodata-io/neodata-go/logger"
+	"go.uber.org/zap"
 )
 
 type NATSClient struct {
-	nc *nats.Conn
-	js jetstream.JetStream
+	nc     *nats.Conn
+	js     jetstream.JetStream
+	logger logger.Logger
 }
 
-// NewNATSClient creates a new NATS JetStream connection
+// NewNATSClient creates a new NATS JetStream connection logging through the global zap logger
 func NewNATSClient(ctx context.Context, natsURL string) (*NATSClient, error) {
+	return NewNATSClientWithLogger(ctx, natsURL, logger.Wrap(zap.L()))
+}
+
+// NewNATSClientWithLogger is NewNATSClient logging the connection events through l, see ConnectionHandlers
+func NewNATSClientWithLogger(ctx context.Context, natsURL string, l logger.Logger) (*NATSClient, error) {
 	// In the `jetstream` package, almost all API calls rely on `context.Context` for timeout/cancellation handling
 	_, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	nc, err := nats.Connect(natsURL)
+	nc, err := nats.Connect(natsURL, ConnectionHandlers(l)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to NATS: %w", err)
 	}
@@ -34,7 +41,7 @@ func NewNATSClient(ctx context.Context, natsURL string) (*NATSClient, error) {
 		return nil, fmt.Errorf("failed to get JetStream context: %w", err)
 	}
 
-	return &NATSClient{nc: nc, js: js}, nil
+	return &NATSClient{nc: nc, js: js, logger: l}, nil
 }
 
 // Close closes the NATS connection
@@ -44,6 +51,43 @@ func (n *NATSClient) Close() {
 	}
 }
 
+// Ping checks that the connection is up and that JetStream answers requests.
+func (n *NATSClient) Ping(ctx context.Context) error {
+	if status := n.nc.Status(); status != nats.CONNECTED {
+		return fmt.Errorf("NATS connection is %s", status)
+	}
+	if _, err := n.js.AccountInfo(ctx); err != nil {
+		return fmt.Errorf("JetStream is not reachable: %w", err)
+	}
+	return nil
+}
+
+// Drain stops new deliveries, lets pending messages be processed and flushes pending
+// publishes before closing the connection. The connection is closed forcefully if ctx ends first.
+func (n *NATSClient) Drain(ctx context.Context) error {
+	if n.nc == nil || n.nc.IsClosed() {
+		return nil
+	}
+	if err := n.nc.Drain(); err != nil {
+		n.nc.Close()
+		return fmt.Errorf("failed to drain NATS connection: %w", err)
+	}
+
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			n.nc.Close()
+			return fmt.Errorf("NATS drain did not complete: %w", ctx.Err())
+		case <-ticker.C:
+			if n.nc.IsClosed() {
+				return nil
+			}
+		}
+	}
+}
+
 // CreateStreams sets up multiple JetStream streams based on the configuration
 func (n *NATSClient) CreateStreams(ctx context.Context, cfg *config.AppConfig) error {
 	// Iterate through the streams defined in the config
@@ -67,7 +111,7 @@ func (n *NATSClient) CreateStreams(ctx context.Context, cfg *config.AppConfig) e
 			return fmt.Errorf("failed to create JetStream stream %s: %v", streamConfig.StreamName, err)
 		}
 
-		log.Printf("Stream %s created successfully with subjects: %v", streamConfig.StreamName, streamConfig.Subjects)
+		n.logger.Info("Stream created", zap.String("stream", streamConfig.StreamName), zap.Strings("subjects", streamConfig.Subjects))
 	}
 
 	return nil
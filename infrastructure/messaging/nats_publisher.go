@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/nats-io/nats.go"
 	"github.com/nats-io/nats.go/jetstream"
 )
 
@@ -25,12 +26,16 @@ func NewPublisher(client *NATSClient, retries int, retryDelay time.Duration) *Pu
 	}
 }
 
-// Publish publishes an event to a specific subject
+// Publish publishes an event to a specific subject. The correlation ID and the trace context of ctx
+// are sent in the message headers, so the logs of the consumers are correlated with the caller.
 func (p *Publisher) Publish(ctx context.Context, subject string, data []byte) (*jetstream.PubAck, error) {
-	// A helper method accepting subject and data as parameters
-	ack, err := p.jetStream.Publish(ctx, subject, data)
+	msg := nats.NewMsg(subject)
+	msg.Data = data
+	injectContext(ctx, msg.Header)
+
+	ack, err := p.jetStream.PublishMsg(ctx, msg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to publish event to subject %s: %v", subject, err)
+		return nil, fmt.Errorf("failed to publish event to subject %s: %w", subject, TranslateError(err))
 	}
 
 	return ack, nil
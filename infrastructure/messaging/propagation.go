@@ -0,0 +1,37 @@
+package messaging
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+	"github.com/neodata-io/neodata-go/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// HeaderCorrelationID carries the correlation ID of the request that caused a message,
+// the same header as HTTP requests.
+const HeaderCorrelationID = "X-Correlation-ID"
+
+// propagator propagates the W3C trace context (traceparent, tracestate) even when tracing is
+// disabled, so the logs of a consumer carry the trace of the publisher, like the HTTP middleware.
+// The propagator set by tracing.NewTracer is used as well when tracing is enabled.
+func propagator() propagation.TextMapPropagator {
+	return propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, otel.GetTextMapPropagator())
+}
+
+// injectContext writes the correlation ID and the trace context of ctx into the message headers.
+func injectContext(ctx context.Context, header nats.Header) {
+	if correlationID := logger.CorrelationID(ctx); correlationID != "" {
+		header.Set(HeaderCorrelationID, correlationID)
+	}
+	propagator().Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// extractContext returns a copy of ctx carrying the correlation ID and the trace context of the message headers.
+func extractContext(ctx context.Context, header nats.Header) context.Context {
+	if correlationID := header.Get(HeaderCorrelationID); correlationID != "" {
+		ctx = logger.WithCorrelationID(ctx, correlationID)
+	}
+	return propagator().Extract(ctx, propagation.HeaderCarrier(header))
+}
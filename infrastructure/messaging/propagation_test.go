@@ -0,0 +1,36 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/neodata-io/neodata-go/logger"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestPropagationRoundTrip(t *testing.T) {
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	spanCtx := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled})
+	ctx := logger.WithCorrelationID(trace.ContextWithSpanContext(context.Background(), spanCtx), "req-1")
+
+	// No tracer provider is set up: the trace context is propagated anyway
+	header := nats.Header{}
+	injectContext(ctx, header)
+	if got, want := propagation.HeaderCarrier(header).Get("traceparent"), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"; got != want {
+		t.Errorf("traceparent = %q, want %q", got, want)
+	}
+	if got := header.Get(HeaderCorrelationID); got != "req-1" {
+		t.Errorf("%s = %q, want req-1", HeaderCorrelationID, got)
+	}
+
+	extracted := extractContext(context.Background(), header)
+	if got := trace.SpanContextFromContext(extracted); got.TraceID() != traceID || got.SpanID() != spanID || !got.IsRemote() {
+		t.Errorf("extracted span context = %v, want the remote one of the publisher", got)
+	}
+	if got := logger.CorrelationID(extracted); got != "req-1" {
+		t.Errorf("extracted correlation ID = %q, want req-1", got)
+	}
+}
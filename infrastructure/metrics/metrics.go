@@ -1,12 +1,7 @@
 package metrics
 
 import (
-	"net/http"
-
-	"github.com/neodata-io/neodata-go/neodata"
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
-	"go.uber.org/zap"
 )
 
 type Metrics struct {
@@ -1,16 +1,19 @@
 package tracing
 
 import (
-	neodata "github.com/neodata-io/neodata-go/infrastructure/metrics"
+	"context"
+
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
-	"go.opentelemetry.io/otel/sdk/trace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 )
 
 type Tracer struct {
-	TracerProvider trace.TracerProvider
+	TracerProvider *sdktrace.TracerProvider
 }
 
 func NewTracer(serviceName string) (*Tracer, error) {
@@ -19,17 +22,25 @@ func NewTracer(serviceName string) (*Tracer, error) {
 		return nil, err
 	}
 
-	provider := trace.NewTracerProvider(
-		trace.WithBatcher(exporter),
-		trace.WithResource(resource.NewWithAttributes(
-			"service.name", serviceName,
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", serviceName),
 		)),
 	)
 	otel.SetTracerProvider(provider)
+	// Trace context is forwarded in HTTP and NATS headers
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
 
 	return &Tracer{TracerProvider: provider}, nil
 }
 
-func (n *neodata.NeoCtx) GetTracer(name string) trace.Tracer {
-	return n.TracerProvider.Tracer(name)
+// Tracer returns a named tracer from the provider.
+func (t *Tracer) Tracer(name string) trace.Tracer {
+	return t.TracerProvider.Tracer(name)
+}
+
+// Shutdown flushes pending spans and stops the provider.
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	return t.TracerProvider.Shutdown(ctx)
 }
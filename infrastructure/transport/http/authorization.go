@@ -0,0 +1,64 @@
+package http
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/neodata-io/neodata-go/errors"
+	"go.uber.org/zap"
+)
+
+// resourceParam matches the {param} placeholders of a resource template.
+var resourceParam = regexp.MustCompile(`\{(\w+)\}`)
+
+// PermissionChecker decides whether a subject may perform an action on a resource.
+// It is implemented by *policy.PolicyManager.
+type PermissionChecker interface {
+	CanUserPerformAction(user string, resource string, action string) (bool, error)
+}
+
+// ResolveResource expands the {param} placeholders of a resource template with the
+// request's path params, e.g. "orders:{id}" becomes "orders:42" for /orders/42.
+func ResolveResource(c fiber.Ctx, resource string) string {
+	return resourceParam.ReplaceAllStringFunc(resource, func(placeholder string) string {
+		return c.Params(resourceParam.FindStringSubmatch(placeholder)[1])
+	})
+}
+
+// RequirePermission only lets requests through when the subject authenticated by AuthMiddleware
+// may perform action on resource. The resource may contain {param} placeholders filled from the path.
+// Every decision is logged for audit.
+func RequirePermission(checker PermissionChecker, logger *zap.Logger, resource, action string) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		subject, ok := c.Locals(LocalsUserID).(string)
+		if !ok || subject == "" {
+			return errors.UnauthorizedError{Detail: "authentication required"}
+		}
+
+		target := ResolveResource(c, resource)
+		correlationID, _ := c.Locals(LocalsCorrelationID).(string)
+		fields := []zap.Field{
+			zap.String("subject", subject),
+			zap.String("resource", target),
+			zap.String("action", action),
+			zap.String("method", c.Method()),
+			zap.String("path", c.Path()),
+			zap.String("correlation_id", correlationID),
+		}
+
+		allowed, err := checker.CanUserPerformAction(subject, target, action)
+		if err != nil {
+			logger.Error("Authorization check failed", append(fields, zap.Error(err))...)
+			return fmt.Errorf("failed to check permission: %w", err)
+		}
+
+		if !allowed {
+			logger.Warn("Authorization denied", append(fields, zap.Bool("allowed", false))...)
+			return errors.ForbiddenError{Detail: fmt.Sprintf("%s on %s is not allowed", action, target)}
+		}
+
+		logger.Info("Authorization granted", append(fields, zap.Bool("allowed", true))...)
+		return c.Next()
+	}
+}
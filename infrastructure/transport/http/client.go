@@ -8,6 +8,7 @@ import (
 
 // NewHTTPClient initializes a shared HTTP client with custom settings.
 // timeout: timeout duration for requests, e.g., 10 * time.Second
+// Errors returned by other services are decoded with DecodeError.
 func NewHTTPClient(timeout time.Duration) *http.Client {
 	return &http.Client{
 		Timeout: timeout,
@@ -0,0 +1,166 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-playground/validator"
+	"github.com/gofiber/fiber/v3"
+	"github.com/neodata-io/neodata-go/config"
+	apperrors "github.com/neodata-io/neodata-go/errors"
+	"github.com/neodata-io/neodata-go/util"
+	"go.uber.org/zap"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem documents.
+const ProblemContentType = "application/problem+json"
+
+// internalErrorDetail replaces the detail of 5xx errors in production.
+const internalErrorDetail = "an internal error occurred"
+
+// ProblemDetails is an RFC 7807 problem document.
+type ProblemDetails struct {
+	Type          string                 `json:"type"`
+	Title         string                 `json:"title"`
+	Status        int                    `json:"status"`
+	Detail        string                 `json:"detail,omitempty"`
+	Instance      string                 `json:"instance,omitempty"`
+	CorrelationID string                 `json:"correlation_id,omitempty"`
+	Code          string                 `json:"code,omitempty"`      // Code of an AppError, e.g. not_found
+	Details       map[string]any         `json:"details,omitempty"`   // Details of an AppError
+	Retryable     bool                   `json:"retryable,omitempty"` // The request may succeed if retried later
+	Errors        []util.ValidationError `json:"errors,omitempty"`    // Field errors of invalid requests
+}
+
+// statusCoder is implemented by the error types of the errors package.
+type statusCoder interface {
+	StatusCode() int
+}
+
+// StatusCode returns the HTTP status for err: the StatusCode of any error in the chain
+// implementing it, the code of a *fiber.Error, 400 for validation errors and 500 otherwise.
+func StatusCode(err error) int {
+	var sc statusCoder
+	if errors.As(err, &sc) {
+		return sc.StatusCode()
+	}
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code
+	}
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// NewProblem builds the problem document for err. The detail of 5xx errors is hidden when hideInternal is set.
+func NewProblem(c fiber.Ctx, err error, hideInternal bool) ProblemDetails {
+	status := StatusCode(err)
+	problem := ProblemDetails{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   err.Error(),
+		Instance: c.OriginalURL(),
+	}
+	if correlationID, ok := c.Locals(LocalsCorrelationID).(string); ok {
+		problem.CorrelationID = correlationID
+	}
+
+	// The message of an AppError is meant for clients, its cause is only logged
+	if appErr, ok := apperrors.As(err); ok {
+		problem.Code = string(appErr.Code)
+		problem.Details = appErr.Details
+		problem.Retryable = appErr.Retryable
+		if appErr.Message != "" {
+			problem.Detail = appErr.Message
+		}
+	}
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		problem.Errors = util.FormatValidationErrors(validationErrs)
+	}
+
+	if status >= http.StatusInternalServerError && hideInternal {
+		problem.Detail = internalErrorDetail
+		problem.Details = nil
+	}
+	return problem
+}
+
+// maxProblemSize bounds the problem documents read by DecodeError.
+const maxProblemSize = 64 << 10
+
+// DecodeError returns the error of a response from another service, nil for successful responses.
+// A problem+json body is decoded into the AppError the service returned, keeping its code, details
+// and retryable flag; other error responses get the code of their status.
+//
+//	resp, err := client.Do(req)
+//	...
+//	defer resp.Body.Close()
+//	if err := http.DecodeError(resp); err != nil {
+//		return err // errors.Is(err, errors.ErrNotFound), errors.IsRetryable(err), ...
+//	}
+func DecodeError(resp *http.Response) error {
+	if resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+
+	var problem ProblemDetails
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxProblemSize))
+	if err != nil || json.Unmarshal(body, &problem) != nil || problem.Status == 0 {
+		return apperrors.FromWire(apperrors.Wire{Status: resp.StatusCode, Message: http.StatusText(resp.StatusCode)})
+	}
+
+	appErr := apperrors.FromWire(apperrors.Wire{
+		Code:      apperrors.Code(problem.Code),
+		Status:    resp.StatusCode,
+		Message:   problem.Detail,
+		Details:   problem.Details,
+		Retryable: problem.Retryable,
+	})
+	if len(problem.Errors) > 0 {
+		appErr = appErr.WithDetail("errors", problem.Errors)
+	}
+	if problem.CorrelationID != "" {
+		appErr = appErr.WithDetail("correlation_id", problem.CorrelationID)
+	}
+	return appErr
+}
+
+// WriteProblem writes err as an application/problem+json response.
+func WriteProblem(c fiber.Ctx, problem ProblemDetails) error {
+	return c.Status(problem.Status).JSON(problem, ProblemContentType)
+}
+
+// ErrorHandler returns the Fiber error handler that turns every error returned by a handler or
+// middleware into a problem+json response. Internal errors are logged with a stack trace and their
+// detail is hidden from clients in the prd environment. Client errors are logged at info level: they
+// are expected, and the development logger attaches a stack trace to every warning.
+func ErrorHandler(cfg *config.AppConfig, logger *zap.Logger) fiber.ErrorHandler {
+	hideInternal := cfg.App.Env == "prd"
+
+	return func(c fiber.Ctx, err error) error {
+		problem := NewProblem(c, err, hideInternal)
+
+		fields := []zap.Field{
+			zap.String("method", c.Method()),
+			zap.String("path", c.Path()),
+			zap.Int("status", problem.Status),
+			zap.String("correlation_id", problem.CorrelationID),
+			zap.Error(err),
+		}
+		if problem.Status >= http.StatusInternalServerError {
+			logger.Error("Request failed", append(fields, zap.Stack("stack"))...)
+		} else {
+			logger.Info("Request rejected", fields...)
+		}
+
+		return WriteProblem(c, problem)
+	}
+}
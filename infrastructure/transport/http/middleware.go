@@ -3,6 +3,7 @@ package http
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -12,48 +13,121 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/neodata-io/neodata-go/domain/entities"
+	"github.com/neodata-io/neodata-go/errors"
+	applogger "github.com/neodata-io/neodata-go/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 	"go.uber.org/zap"
 )
 
+// Keys of the values stored in the request Locals by the middlewares.
+const (
+	LocalsCorrelationID = "correlation_id"
+	LocalsUserID        = "userID"
+	LocalsAbilities     = "abilities"
+	LocalsClaims        = "claims"
+)
+
 type ValidationResponse struct {
 	Valid bool   `json:"valid"`
 	Sub   string `json:"sub"`
 	Role  string `json:"role"`
 }
 
-// ZapLoggerMiddleware logs request details using Zap logger
+// ZapLoggerMiddleware attaches a child logger carrying the correlation ID, method and path to the
+// request context, see logger.FromContext, and logs request details once the request is processed.
+// Errors of the next handlers are rendered by the app's ErrorHandler before the status is logged.
 func ZapLoggerMiddleware(logger *zap.Logger) fiber.Handler {
 	return func(c fiber.Ctx) error {
 		start := time.Now() // Capture start time
 
-		err := c.Next() // Process request
+		correlationID, _ := c.Locals(LocalsCorrelationID).(string)
+		ctx := applogger.WithCorrelationID(c.UserContext(), correlationID)
+		ctx = applogger.WithContext(ctx, logger.With(
+			zap.String("correlation_id", correlationID),
+			zap.String("method", c.Method()), // HTTP method (GET, POST, etc.)
+			zap.String("path", c.Path()),     // Request path
+		))
+		c.SetUserContext(ctx)
+
+		// Render errors here rather than after the middleware chain, so the logged status is the one sent
+		if err := c.Next(); err != nil {
+			if err := c.App().Config().ErrorHandler(c, err); err != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
 
 		// Calculate latency
 		latency := time.Since(start)
 
-		// Log details of the request
-		logger.Info("Request",
-			zap.String("method", c.Method()),                                  // HTTP method (GET, POST, etc.)
-			zap.String("path", c.Path()),                                      // Request path
-			zap.Int("status", c.Response().StatusCode()),                      // Status code (200, 404, etc.)
-			zap.Duration("latency", latency),                                  // Time taken to process the request
-			zap.String("correlation_id", c.Locals("correlation_id").(string)), // Example: custom request ID
+		// Log details of the request, with the fields added by later middlewares such as the user ID
+		applogger.FromContext(c.UserContext()).Info("Request",
+			zap.String("route", c.Route().Path),          // Route pattern, e.g. /orders/:id
+			zap.Int("status", c.Response().StatusCode()), // Status code (200, 404, etc.)
+			zap.Duration("latency", latency),             // Time taken to process the request
 		)
 
-		return err // Return any errors from the next handler
+		return nil // Errors are already rendered
 	}
 }
 
 // LoggerMiddleware provides structured and stylized request logging.
+// Sensitive values of bodies, headers and query strings are redacted with the default rules.
 func LoggerMiddleware() fiber.Handler {
+	return LoggerMiddlewareWithRedactor(applogger.DefaultRedactor())
+}
+
+// LoggerMiddlewareWithRedactor is LoggerMiddleware redacting with the given rules, usually the ones
+// of logger.redaction, see logger.RedactorFromConfig. A nil redactor logs every value as is.
+func LoggerMiddlewareWithRedactor(redactor *applogger.Redactor) fiber.Handler {
 	return logger.New(logger.Config{
 		Format:     "[${time}] ${status} - ${method} ${path} ${latency} ${locals:requestid} ${body} \n",
 		TimeFormat: "15:04:05",
 		TimeZone:   "Local",
 		Output:     os.Stdout, // Ensures output to standard log console.
+		CustomTags: redactedTags(redactor),
 	})
 }
 
+// redactedTags replaces the logger tags printing bodies, headers, query strings, forms and cookies.
+func redactedTags(redactor *applogger.Redactor) map[string]logger.LogFunc {
+	return map[string]logger.LogFunc{
+		logger.TagBody: func(output logger.Buffer, c fiber.Ctx, _ *logger.Data, _ string) (int, error) {
+			return output.Write(redactor.RedactBody(c.Get(fiber.HeaderContentType), c.Body()))
+		},
+		logger.TagResBody: func(output logger.Buffer, c fiber.Ctx, _ *logger.Data, _ string) (int, error) {
+			return output.Write(redactor.RedactBody(c.GetRespHeader(fiber.HeaderContentType), c.Response().Body()))
+		},
+		logger.TagReqHeaders: func(output logger.Buffer, c fiber.Ctx, _ *logger.Data, _ string) (int, error) {
+			headers := redactor.RedactHeaders(c.GetReqHeaders())
+			pairs := make([]string, 0, len(headers))
+			for key, values := range headers {
+				pairs = append(pairs, key+"="+strings.Join(values, ","))
+			}
+			sort.Strings(pairs)
+			return output.WriteString(strings.Join(pairs, "&"))
+		},
+		logger.TagReqHeader: func(output logger.Buffer, c fiber.Ctx, _ *logger.Data, header string) (int, error) {
+			return output.WriteString(redactor.RedactValue(header, c.Get(header)))
+		},
+		logger.TagRespHeader: func(output logger.Buffer, c fiber.Ctx, _ *logger.Data, header string) (int, error) {
+			return output.WriteString(redactor.RedactValue(header, c.GetRespHeader(header)))
+		},
+		logger.TagQueryStringParams: func(output logger.Buffer, c fiber.Ctx, _ *logger.Data, _ string) (int, error) {
+			return output.WriteString(redactor.RedactQuery(c.Request().URI().QueryArgs().String()))
+		},
+		logger.TagQuery: func(output logger.Buffer, c fiber.Ctx, _ *logger.Data, param string) (int, error) {
+			return output.WriteString(redactor.RedactValue(param, c.Query(param)))
+		},
+		logger.TagForm: func(output logger.Buffer, c fiber.Ctx, _ *logger.Data, field string) (int, error) {
+			return output.WriteString(redactor.RedactValue(field, c.FormValue(field)))
+		},
+		logger.TagCookie: func(output logger.Buffer, c fiber.Ctx, _ *logger.Data, name string) (int, error) {
+			return output.WriteString(redactor.RedactValue(name, c.Cookies(name)))
+		},
+	}
+}
+
 // RateLimiterMiddleware provides rate limiting based on request count per time unit.
 func RateLimiterMiddleware(maxRequests int, duration time.Duration) fiber.Handler {
 	return limiter.New(limiter.Config{
@@ -62,6 +136,35 @@ func RateLimiterMiddleware(maxRequests int, duration time.Duration) fiber.Handle
 	})
 }
 
+// TraceContextMiddleware continues the trace of the caller: the W3C trace context of the request
+// headers (traceparent, tracestate) is extracted into the user context, so the request logs carry
+// its trace_id and span_id. The propagator set by tracing.NewTracer is used as well when tracing
+// is enabled.
+func TraceContextMiddleware() fiber.Handler {
+	return func(c fiber.Ctx) error {
+		propagator := propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, otel.GetTextMapPropagator())
+		c.SetUserContext(propagator.Extract(c.UserContext(), headerCarrier{c}))
+		return c.Next()
+	}
+}
+
+// headerCarrier adapts the request headers to a propagation.TextMapCarrier.
+type headerCarrier struct {
+	c fiber.Ctx
+}
+
+func (h headerCarrier) Get(key string) string { return h.c.Get(key) }
+
+func (h headerCarrier) Set(key, value string) { h.c.Request().Header.Set(key, value) }
+
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(h.c.GetReqHeaders()))
+	for key := range h.c.GetReqHeaders() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
 func CorrelationIDMiddleware() fiber.Handler {
 	return func(c fiber.Ctx) error {
 		// Check if Correlation ID exists in the incoming request header
@@ -73,7 +176,7 @@ func CorrelationIDMiddleware() fiber.Handler {
 		}
 
 		// Attach the Correlation ID to the request context for use in other functions
-		c.Locals("correlation_id", correlationID)
+		c.Locals(LocalsCorrelationID, correlationID)
 
 		return c.Next()
 	}
@@ -85,7 +188,7 @@ func AuthMiddleware(secretKey string) fiber.Handler {
 		// Extract token from Authorization header
 		authHeader := c.Get("Authorization")
 		if len(authHeader) <= len("Bearer ") {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "authorization header missing or malformed"})
+			return errors.UnauthorizedError{Detail: "authorization header missing or malformed"}
 		}
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 
@@ -101,15 +204,17 @@ func AuthMiddleware(secretKey string) fiber.Handler {
 
 		// Handle token parsing or validation errors.
 		if err != nil || !token.Valid {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "invalid or expired token"})
+			return errors.UnauthorizedError{Detail: "invalid or expired token"}
 		}
 
 		// Extract claims and store user data in context
 		if claims, ok := token.Claims.(*entities.Claims); ok && token.Valid {
-			c.Locals("userID", claims.UserID)
-			c.Locals("abilities", claims.Abilities)
+			c.Locals(LocalsUserID, claims.UserID)
+			c.Locals(LocalsAbilities, claims.Abilities)
+			c.Locals(LocalsClaims, claims)
+			c.SetUserContext(applogger.WithFields(c.UserContext(), zap.String("user_id", claims.UserID)))
 		} else {
-			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "invalid token claims"})
+			return errors.UnauthorizedError{Detail: "invalid token claims"}
 		}
 
 		// Continue to the next middleware or handler.
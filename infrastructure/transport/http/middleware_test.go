@@ -0,0 +1,131 @@
+package http
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/logger"
+	"github.com/neodata-io/neodata-go/config"
+	"github.com/neodata-io/neodata-go/errors"
+	applogger "github.com/neodata-io/neodata-go/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newTestServer(t *testing.T) (*fiber.App, *observer.ObservedLogs) {
+	t.Helper()
+	core, logs := observer.New(zap.InfoLevel)
+	cfg := &config.AppConfig{}
+	cfg.App.Name, cfg.App.Env = "test", "prd"
+	return NewHTTPServer(cfg, zap.New(core)), logs
+}
+
+func TestZapLoggerMiddlewareLogsErrorStatus(t *testing.T) {
+	app, logs := newTestServer(t)
+	app.Get("/orders/:id", func(c fiber.Ctx) error {
+		return errors.NotFound("order not found")
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/orders/42", nil))
+	if err != nil {
+		t.Fatalf("Test() error = %v", err)
+	}
+	if resp.StatusCode != fiber.StatusNotFound {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, fiber.StatusNotFound)
+	}
+
+	entries := logs.FilterMessage("Request").All()
+	if len(entries) != 1 {
+		t.Fatalf("logged %d requests, want 1", len(entries))
+	}
+	if status := entries[0].ContextMap()["status"]; status != int64(fiber.StatusNotFound) {
+		t.Errorf("logged status = %v, want %d", status, fiber.StatusNotFound)
+	}
+	if route := entries[0].ContextMap()["route"]; route != "/orders/:id" {
+		t.Errorf("logged route = %v, want /orders/:id", route)
+	}
+}
+
+func TestTraceContextMiddlewareAddsTraceFields(t *testing.T) {
+	app, logs := newTestServer(t)
+	app.Get("/orders", func(c fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	req := httptest.NewRequest("GET", "/orders", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	if _, err := app.Test(req); err != nil {
+		t.Fatalf("Test() error = %v", err)
+	}
+
+	entries := logs.FilterMessage("Request").All()
+	if len(entries) != 1 {
+		t.Fatalf("logged %d requests, want 1", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	if fields["trace_id"] != "4bf92f3577b34da6a3ce929d0e0e4736" || fields["span_id"] != "00f067aa0ba902b7" {
+		t.Errorf("logged trace fields = %v, %v, want the ones of traceparent", fields["trace_id"], fields["span_id"])
+	}
+}
+
+func TestRedactedTags(t *testing.T) {
+	var out bytes.Buffer
+	app := fiber.New()
+	app.Use(logger.New(logger.Config{
+		Format:     "${queryParams} ${reqHeader:Authorization} ${body}\n",
+		Output:     &out,
+		CustomTags: redactedTags(applogger.DefaultRedactor()),
+	}))
+	app.Post("/login", func(c fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	req := httptest.NewRequest("POST", "/login?token=t1&next=home", strings.NewReader(`{"user":"jane","password":"hunter2"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer abc")
+	if _, err := app.Test(req); err != nil {
+		t.Fatalf("Test() error = %v", err)
+	}
+
+	logged := out.String()
+	for _, secret := range []string{"t1", "Bearer abc", "hunter2"} {
+		if strings.Contains(logged, secret) {
+			t.Errorf("logged %q, want %q redacted", logged, secret)
+		}
+	}
+	if !strings.Contains(logged, "next=home") || !strings.Contains(logged, `"user":"jane"`) {
+		t.Errorf("logged %q, want the other values kept", logged)
+	}
+}
+
+func TestErrorHandlerLogLevels(t *testing.T) {
+	app, logs := newTestServer(t)
+	app.Get("/orders/:id", func(c fiber.Ctx) error {
+		return errors.NotFound("order not found")
+	})
+	app.Post("/orders", func(c fiber.Ctx) error {
+		return errors.Internal("database unavailable")
+	})
+
+	for _, req := range []*http.Request{httptest.NewRequest("GET", "/orders/42", nil), httptest.NewRequest("POST", "/orders", nil)} {
+		if _, err := app.Test(req); err != nil {
+			t.Fatalf("Test() error = %v", err)
+		}
+	}
+
+	rejected := logs.FilterMessage("Request rejected").All()
+	if len(rejected) != 1 || rejected[0].Level != zap.InfoLevel || rejected[0].Stack != "" {
+		t.Errorf("client error logged as %+v, want once at info level without stack", rejected)
+	}
+	failed := logs.FilterMessage("Request failed").All()
+	if len(failed) != 1 || failed[0].Level != zap.ErrorLevel {
+		t.Fatalf("server error logged as %+v, want once at error level", failed)
+	}
+	if _, ok := failed[0].ContextMap()["stack"]; !ok {
+		t.Error("server error logged without stack")
+	}
+}
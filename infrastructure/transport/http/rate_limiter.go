@@ -0,0 +1,54 @@
+package http
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// RateLimiter is a rate limiting middleware whose limit can be changed at runtime,
+// e.g. when app.rate_limit is reloaded.
+type RateLimiter struct {
+	duration time.Duration
+	handler  atomic.Pointer[fiber.Handler]
+	exempt   sync.Map // Paths served without rate limiting, see Exempt
+}
+
+// NewRateLimiter allows maxRequests per client IP and duration. A limit of 0 disables rate limiting.
+func NewRateLimiter(maxRequests int, duration time.Duration) *RateLimiter {
+	rl := &RateLimiter{duration: duration}
+	rl.SetLimit(maxRequests)
+	return rl
+}
+
+// SetLimit changes the number of requests allowed per duration; 0 disables rate limiting.
+// The request counters restart with the new limit.
+func (rl *RateLimiter) SetLimit(maxRequests int) {
+	handler := func(c fiber.Ctx) error {
+		return c.Next()
+	}
+	if maxRequests > 0 {
+		handler = RateLimiterMiddleware(maxRequests, rl.duration)
+	}
+	rl.handler.Store(&handler)
+}
+
+// Exempt serves the given paths without rate limiting, e.g. the health checks probed by the
+// orchestrator. Authenticated endpoints must stay limited against token guessing.
+func (rl *RateLimiter) Exempt(paths ...string) {
+	for _, path := range paths {
+		rl.exempt.Store(path, struct{}{})
+	}
+}
+
+// Handler returns the middleware enforcing the current limit.
+func (rl *RateLimiter) Handler() fiber.Handler {
+	return func(c fiber.Ctx) error {
+		if _, ok := rl.exempt.Load(c.Path()); ok {
+			return c.Next()
+		}
+		return (*rl.handler.Load())(c)
+	}
+}
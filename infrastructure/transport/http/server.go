@@ -12,22 +12,27 @@ import (
 )
 
 // SetupHTTPServer initializes a new HTTP server with the provided configuration and middleware.
-func NewHTTPServer(cfg *config.AppConfig, logger *zap.Logger) *fiber.App {
+// The given middlewares run for every request after the built-in ones.
+func NewHTTPServer(cfg *config.AppConfig, logger *zap.Logger, middlewares ...fiber.Handler) *fiber.App {
 	app := fiber.New(fiber.Config{
 		ReadTimeout:  cfg.App.ReadTimeout * time.Second,
 		WriteTimeout: cfg.App.WriteTimeout * time.Second,
 		AppName:      cfg.App.Name,
+		ErrorHandler: ErrorHandler(cfg, logger), // Errors are rendered as problem+json
 	})
 
 	// Middleware setup
 	app.Use(CorrelationIDMiddleware()) // CorrelationIDMiddleware for all requests
+	app.Use(TraceContextMiddleware())  // Before the logger, so request logs carry the trace of the caller
 	app.Use(ZapLoggerMiddleware(logger))
 
 	if cfg.App.Env == "dev" {
 		// Allow all methods and headers from localhost for development purposes
 		app.Use(cors.New())
 	}
-	// app.Use(RateLimiterMiddleware(100, time.Minute)) // Rate limiting for protected endpoints
+	for _, middleware := range middlewares {
+		app.Use(middleware)
+	}
 
 	return app
 }
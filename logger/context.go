@@ -0,0 +1,70 @@
+package logger
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type loggerKey struct{}
+
+type correlationIDKey struct{}
+
+// WithContext returns a copy of ctx carrying the logger, see FromContext.
+// The HTTP middlewares and the NATS subscriber attach a logger to every request and message.
+func WithContext(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger attached to ctx, or the global zap logger if there is none, see
+// neodata.WithGlobalLogger.
+// The trace and span IDs of the span in ctx are added to it, so logs of nested spans point to them.
+//
+//	func (r *OrderRepository) Get(ctx context.Context, id string) (*Order, error) {
+//		logger.FromContext(ctx).Debug("Loading order", zap.String("order_id", id))
+//		...
+//	}
+func FromContext(ctx context.Context) *zap.Logger {
+	logger, ok := ctx.Value(loggerKey{}).(*zap.Logger)
+	if !ok {
+		logger = zap.L()
+	}
+	if fields := TraceFields(ctx); len(fields) > 0 {
+		return logger.With(fields...)
+	}
+	return logger
+}
+
+// WithFields returns a copy of ctx whose logger carries the additional fields.
+func WithFields(ctx context.Context, fields ...zap.Field) context.Context {
+	logger, ok := ctx.Value(loggerKey{}).(*zap.Logger)
+	if !ok {
+		logger = zap.L()
+	}
+	return WithContext(ctx, logger.With(fields...))
+}
+
+// TraceFields returns the trace_id and span_id fields of the span in ctx, nil without a valid span.
+func TraceFields(ctx context.Context) []zap.Field {
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		return nil
+	}
+	return []zap.Field{
+		zap.String("trace_id", spanCtx.TraceID().String()),
+		zap.String("span_id", spanCtx.SpanID().String()),
+	}
+}
+
+// WithCorrelationID returns a copy of ctx carrying the correlation ID of the request or message,
+// which publishers forward to the services they call.
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, correlationID)
+}
+
+// CorrelationID returns the correlation ID carried by ctx, or an empty string.
+func CorrelationID(ctx context.Context) string {
+	correlationID, _ := ctx.Value(correlationIDKey{}).(string)
+	return correlationID
+}
@@ -0,0 +1,122 @@
+package logger
+
+import (
+	"fmt"
+
+	"github.com/neodata-io/neodata-go/config"
+	"go.uber.org/zap/zapcore"
+)
+
+// Encodings of the logs.
+const (
+	EncodingJSON    = "json"
+	EncodingConsole = "console"
+)
+
+// Field name schemes of the JSON encoder, matching what the log backends parse without extra mapping.
+const (
+	FieldNamesDefault = "default"
+	FieldNamesECS     = "ecs"     // Elastic Common Schema
+	FieldNamesGCP     = "gcp"     // Google Cloud Logging
+	FieldNamesDatadog = "datadog" // Datadog
+)
+
+// newEncoder creates the encoder of the logs. Colors are only used by the console encoder when
+// color is set, so JSON logs and the logs written to files never contain ANSI codes.
+func newEncoder(cfg config.LoggerConfig, environment string, color bool) (zapcore.Encoder, error) {
+	encoding := cfg.Encoding
+	if encoding == "" {
+		encoding = EncodingConsole
+		if environment == "prd" {
+			encoding = EncodingJSON
+		}
+	}
+
+	switch encoding {
+	case EncodingConsole:
+		encoderConfig := defaultEncoderConfig()
+		if color {
+			encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder // Enables color for log levels
+		}
+		return zapcore.NewConsoleEncoder(encoderConfig), nil
+	case EncodingJSON:
+		encoderConfig, err := jsonEncoderConfig(cfg.FieldNames)
+		if err != nil {
+			return nil, err
+		}
+		return zapcore.NewJSONEncoder(encoderConfig), nil
+	default:
+		return nil, fmt.Errorf("invalid log encoding: %s", encoding)
+	}
+}
+
+// defaultEncoderConfig sets the encoder keys for structured logs (log level, message, caller)
+func defaultEncoderConfig() zapcore.EncoderConfig {
+	return zapcore.EncoderConfig{
+		TimeKey:        "timestamp",
+		LevelKey:       "level",
+		NameKey:        "logger",
+		CallerKey:      "caller",
+		MessageKey:     "message",
+		StacktraceKey:  "stacktrace",
+		LineEnding:     zapcore.DefaultLineEnding,
+		EncodeTime:     zapcore.RFC3339TimeEncoder,
+		EncodeLevel:    zapcore.CapitalLevelEncoder,
+		EncodeDuration: zapcore.StringDurationEncoder,
+		EncodeCaller:   zapcore.ShortCallerEncoder, // Short file path for caller info
+	}
+}
+
+// jsonEncoderConfig returns the encoder config of the given field name scheme.
+func jsonEncoderConfig(fieldNames string) (zapcore.EncoderConfig, error) {
+	encoderConfig := defaultEncoderConfig()
+
+	switch fieldNames {
+	case "", FieldNamesDefault:
+	case FieldNamesECS:
+		encoderConfig.TimeKey = "@timestamp"
+		encoderConfig.LevelKey = "log.level"
+		encoderConfig.NameKey = "log.logger"
+		encoderConfig.CallerKey = "log.origin.file.name"
+		encoderConfig.StacktraceKey = "error.stack_trace"
+		encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+		encoderConfig.EncodeLevel = zapcore.LowercaseLevelEncoder
+	case FieldNamesGCP:
+		encoderConfig.TimeKey = "time"
+		encoderConfig.LevelKey = "severity"
+		encoderConfig.StacktraceKey = "stack_trace"
+		encoderConfig.EncodeTime = zapcore.RFC3339NanoTimeEncoder
+		encoderConfig.EncodeLevel = gcpSeverityEncoder
+	case FieldNamesDatadog:
+		encoderConfig.LevelKey = "status"
+		encoderConfig.NameKey = "logger.name"
+		encoderConfig.StacktraceKey = "error.stack"
+		encoderConfig.EncodeTime = zapcore.EpochMillisTimeEncoder
+		encoderConfig.EncodeLevel = zapcore.LowercaseLevelEncoder
+	default:
+		return encoderConfig, fmt.Errorf("invalid log field names: %s", fieldNames)
+	}
+	return encoderConfig, nil
+}
+
+// gcpSeverityEncoder encodes levels as Cloud Logging severities.
+func gcpSeverityEncoder(level zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	switch level {
+	case zapcore.DebugLevel:
+		enc.AppendString("DEBUG")
+	case zapcore.InfoLevel:
+		enc.AppendString("INFO")
+	case zapcore.WarnLevel:
+		enc.AppendString("WARNING")
+	case zapcore.ErrorLevel:
+		enc.AppendString("ERROR")
+	case zapcore.DPanicLevel:
+		enc.AppendString("CRITICAL")
+	case zapcore.PanicLevel:
+		enc.AppendString("ALERT")
+	case zapcore.FatalLevel:
+		enc.AppendString("EMERGENCY")
+	default:
+		enc.AppendString("DEFAULT")
+	}
+}
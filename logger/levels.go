@@ -0,0 +1,204 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Modules of the framework with their own logger, see Levels.Named.
+const (
+	ModuleHTTP      = "http"
+	ModuleMessaging = "messaging"
+	ModuleDB        = "db"
+	ModulePolicy    = "policy"
+)
+
+// Levels holds the level of a logger and the levels of its modules. A module follows the root level
+// until its own level is set, which may be more verbose than the root one, e.g. debug for messaging
+// while everything else logs at info.
+type Levels struct {
+	root zap.AtomicLevel
+
+	mu      sync.RWMutex
+	modules map[string]*moduleLevel
+}
+
+// NewLevels creates the levels of a logger whose level is root, with the modules of the framework.
+func NewLevels(root zap.AtomicLevel) *Levels {
+	levels := &Levels{
+		root:    root,
+		modules: make(map[string]*moduleLevel),
+	}
+	for _, module := range []string{ModuleHTTP, ModuleMessaging, ModuleDB, ModulePolicy} {
+		levels.module(module)
+	}
+	return levels
+}
+
+// Root returns the level of the logger.
+func (l *Levels) Root() zap.AtomicLevel {
+	return l.root
+}
+
+// Named returns the logger of a module, named after it, whose level is the level of the module.
+// logger should be created by NewLoggerFromConfig; the level of other loggers can only be raised.
+func (l *Levels) Named(logger *zap.Logger, module string) *zap.Logger {
+	level := l.module(module)
+	return logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		if lc, ok := core.(*levelCore); ok {
+			return &levelCore{Core: lc.Core, enabler: level, closer: lc.closer}
+		}
+		return &levelCore{Core: core, enabler: level}
+	})).Named(module)
+}
+
+// SetModuleLevel sets the level of a module.
+func (l *Levels) SetModuleLevel(module string, level zapcore.Level) error {
+	ml, ok := l.lookup(module)
+	if !ok {
+		return fmt.Errorf("unknown log module: %s", module)
+	}
+	ml.set(&level)
+	return nil
+}
+
+// SetModuleLevels sets the levels of logger.modules; modules missing from it follow the root level.
+func (l *Levels) SetModuleLevels(levels map[string]string) error {
+	parsed := make(map[string]zapcore.Level, len(levels))
+	for module, name := range levels {
+		if _, ok := l.lookup(module); !ok {
+			return fmt.Errorf("unknown log module: %s", module)
+		}
+		level, err := ParseLevel(name)
+		if err != nil {
+			return fmt.Errorf("failed to set level of log module %s: %w", module, err)
+		}
+		parsed[module] = level
+	}
+
+	for _, module := range l.Modules() {
+		ml, _ := l.lookup(module)
+		if level, ok := parsed[module]; ok {
+			ml.set(&level)
+		} else {
+			ml.set(nil)
+		}
+	}
+	return nil
+}
+
+// ResetModuleLevel makes a module follow the root level again.
+func (l *Levels) ResetModuleLevel(module string) error {
+	ml, ok := l.lookup(module)
+	if !ok {
+		return fmt.Errorf("unknown log module: %s", module)
+	}
+	ml.set(nil)
+	return nil
+}
+
+// ModuleLevels returns the effective level of every module.
+func (l *Levels) ModuleLevels() map[string]zapcore.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	levels := make(map[string]zapcore.Level, len(l.modules))
+	for name, ml := range l.modules {
+		levels[name] = ml.Level()
+	}
+	return levels
+}
+
+// Modules returns the names of the modules in sorted order.
+func (l *Levels) Modules() []string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	names := make([]string, 0, len(l.modules))
+	for name := range l.modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (l *Levels) lookup(module string) (*moduleLevel, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	ml, ok := l.modules[module]
+	return ml, ok
+}
+
+// module returns the level of a module, registering it on first use.
+func (l *Levels) module(module string) *moduleLevel {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	ml, ok := l.modules[module]
+	if !ok {
+		ml = &moduleLevel{root: l.root}
+		l.modules[module] = ml
+	}
+	return ml
+}
+
+// moduleLevel is the level of a module, the root level until it is set.
+type moduleLevel struct {
+	root zap.AtomicLevel
+
+	mu    sync.RWMutex
+	level *zapcore.Level
+}
+
+func (m *moduleLevel) set(level *zapcore.Level) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.level = level
+}
+
+// Level implements zapcore.LevelOf.
+func (m *moduleLevel) Level() zapcore.Level {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.level == nil {
+		return m.root.Level()
+	}
+	return *m.level
+}
+
+// Enabled implements zapcore.LevelEnabler.
+func (m *moduleLevel) Enabled(level zapcore.Level) bool {
+	return level >= m.Level()
+}
+
+// levelCore filters the entries of a core that accepts every level, so the loggers of the
+// modules can be more verbose than the root logger sharing the core.
+type levelCore struct {
+	zapcore.Core
+	enabler zapcore.LevelEnabler
+	closer  io.Closer // Outputs of the core, see Close
+}
+
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return c.enabler.Enabled(level)
+}
+
+func (c *levelCore) Level() zapcore.Level {
+	return zapcore.LevelOf(c.enabler)
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), enabler: c.enabler, closer: c.closer}
+}
+
+func (c *levelCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.enabler.Enabled(entry.Level) {
+		return checked
+	}
+	return c.Core.Check(entry, checked)
+}
@@ -0,0 +1,91 @@
+package logger
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestModuleLevels(t *testing.T) {
+	levels := NewLevels(zap.NewAtomicLevelAt(zap.InfoLevel))
+	if got := levels.ModuleLevels()[ModuleMessaging]; got != zap.InfoLevel {
+		t.Fatalf("messaging level = %s, want the root level info", got)
+	}
+
+	if err := levels.SetModuleLevel(ModuleMessaging, zap.DebugLevel); err != nil {
+		t.Fatalf("SetModuleLevel() error = %v", err)
+	}
+	levels.Root().SetLevel(zap.ErrorLevel)
+	got := levels.ModuleLevels()
+	if got[ModuleMessaging] != zap.DebugLevel || got[ModuleDB] != zap.ErrorLevel {
+		t.Errorf("module levels = %v, want messaging at debug and the others following the root level", got)
+	}
+
+	if err := levels.ResetModuleLevel(ModuleMessaging); err != nil {
+		t.Fatalf("ResetModuleLevel() error = %v", err)
+	}
+	if got := levels.ModuleLevels()[ModuleMessaging]; got != zap.ErrorLevel {
+		t.Errorf("messaging level after reset = %s, want error", got)
+	}
+
+	if err := levels.SetModuleLevel("kafka", zap.DebugLevel); err == nil {
+		t.Error("SetModuleLevel() of an unknown module error = nil")
+	}
+	if err := levels.ResetModuleLevel("kafka"); err == nil {
+		t.Error("ResetModuleLevel() of an unknown module error = nil")
+	}
+}
+
+func TestSetModuleLevels(t *testing.T) {
+	levels := NewLevels(zap.NewAtomicLevelAt(zap.InfoLevel))
+	_ = levels.SetModuleLevel(ModuleDB, zap.WarnLevel)
+
+	if err := levels.SetModuleLevels(map[string]string{ModuleHTTP: "debug"}); err != nil {
+		t.Fatalf("SetModuleLevels() error = %v", err)
+	}
+	got := levels.ModuleLevels()
+	if got[ModuleHTTP] != zap.DebugLevel || got[ModuleDB] != zap.InfoLevel {
+		t.Errorf("module levels = %v, want http at debug and db following the root level again", got)
+	}
+
+	for _, invalid := range []map[string]string{{"kafka": "debug"}, {ModuleHTTP: "verbose"}} {
+		if err := levels.SetModuleLevels(invalid); err == nil {
+			t.Errorf("SetModuleLevels(%v) error = nil", invalid)
+		}
+	}
+	// Invalid levels leave the previous ones in place
+	if got := levels.ModuleLevels()[ModuleHTTP]; got != zap.DebugLevel {
+		t.Errorf("http level after an invalid change = %s, want debug", got)
+	}
+}
+
+func TestNamed(t *testing.T) {
+	levels := NewLevels(zap.NewAtomicLevelAt(zap.InfoLevel))
+	core, logs := observer.New(zapcore.DebugLevel)
+	root := zap.New(&levelCore{Core: core, enabler: levels.Root()})
+	messaging := levels.Named(root, ModuleMessaging)
+	_ = levels.SetModuleLevel(ModuleMessaging, zap.DebugLevel)
+
+	root.Debug("Root debug")
+	messaging.Debug("Message received")
+	levels.Named(root, ModuleDB).Debug("Query")
+	// Loggers derived from a module logger keep its level
+	messaging.With(zap.String("subject", "orders")).Debug("Message acked")
+
+	entries := logs.AllUntimed()
+	if len(entries) != 2 || entries[0].Message != "Message received" || entries[1].Message != "Message acked" {
+		t.Fatalf("entries = %v, want the debug entries of messaging only", entries)
+	}
+	if entries[0].LoggerName != ModuleMessaging {
+		t.Errorf("logger name = %q, want %q", entries[0].LoggerName, ModuleMessaging)
+	}
+
+	// Module loggers created before a change follow it
+	_ = levels.SetModuleLevel(ModuleMessaging, zap.ErrorLevel)
+	messaging.Warn("Slow consumer")
+	if logs.Len() != 2 {
+		t.Error("warning logged after the messaging level was raised to error")
+	}
+}
@@ -2,6 +2,7 @@ package logger
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/neodata-io/neodata-go/config"
@@ -9,66 +10,153 @@ import (
 	"go.uber.org/zap/zapcore"
 )
 
+// Logger is the logger used by the framework adapters, see Wrap for a *zap.Logger.
 type Logger interface {
+	Debug(msg string, fields ...zap.Field)
 	Info(msg string, fields ...zap.Field)
+	Warn(msg string, fields ...zap.Field)
 	Error(msg string, fields ...zap.Field)
-	// other logging methods...
+
+	// With returns a child logger adding the fields to every entry
+	With(fields ...zap.Field) Logger
+	// Named returns a child logger whose name is extended with name
+	Named(name string) Logger
+	// Sync flushes buffered entries
+	Sync() error
 }
 
-// NewLogger creates a logger based on the log level and environment.
-func NewLogger(logLevel zapcore.Level, environment string) (*zap.Logger, error) {
-	var config zap.Config
+// LevelEnabler is implemented by loggers that can tell whether a level is logged, which the
+// adapters use to skip building entries that would be dropped.
+type LevelEnabler interface {
+	Enabled(level zapcore.Level) bool
+}
 
-	// Choose between prd and dev configurations
-	if environment == "prd" {
-		config = zap.NewProductionConfig()
-	} else {
-		config = zap.NewDevelopmentConfig()
-	}
+// Wrap returns the Logger of a *zap.Logger.
+func Wrap(l *zap.Logger) Logger {
+	return zapLogger{l}
+}
 
-	// Set the log level passed by the microservice
-	config.Level = zap.NewAtomicLevelAt(logLevel)
+// zapLogger implements Logger and LevelEnabler with a *zap.Logger.
+type zapLogger struct {
+	*zap.Logger
+}
 
-	// Set encoder keys for structured logs (log level, message, caller)
-	config.EncoderConfig = zapcore.EncoderConfig{
-		TimeKey:      "timestamp",
-		LevelKey:     "level",
-		MessageKey:   "message",
-		CallerKey:    "caller",
-		EncodeTime:   zapcore.RFC3339TimeEncoder,
-		EncodeLevel:  zapcore.CapitalColorLevelEncoder, // Enables color for log levels
-		EncodeCaller: zapcore.ShortCallerEncoder,       // Short file path for caller info
+func (l zapLogger) With(fields ...zap.Field) Logger {
+	return zapLogger{l.Logger.With(fields...)}
+}
 
+func (l zapLogger) Named(name string) Logger {
+	return zapLogger{l.Logger.Named(name)}
+}
+
+func (l zapLogger) Enabled(level zapcore.Level) bool {
+	return l.Core().Enabled(level)
+}
+
+// AddCallerSkip returns l reporting as caller the function skip frames above the one calling it,
+// e.g. the library calling an adapter rather than the adapter itself. Loggers not returned by Wrap
+// are returned as is.
+func AddCallerSkip(l Logger, skip int) Logger {
+	if zl, ok := l.(zapLogger); ok {
+		return zapLogger{zl.WithOptions(zap.AddCallerSkip(skip))}
+	}
+	return l
+}
+
+// Enabled reports whether l logs entries of level, true for loggers not implementing LevelEnabler.
+func Enabled(l Logger, level zapcore.Level) bool {
+	if enabler, ok := l.(LevelEnabler); ok {
+		return enabler.Enabled(level)
 	}
+	return true
+}
+
+// NewLogger creates a logger based on the log level and environment.
+func NewLogger(logLevel zapcore.Level, environment string) (*zap.Logger, error) {
+	return NewLoggerWithLevel(zap.NewAtomicLevelAt(logLevel), environment)
+}
+
+// NewLoggerWithLevel creates a logger whose level follows the given AtomicLevel, so it can be changed at runtime.
+// It writes to stdout with the encoder and sampling of the environment, see NewLoggerFromConfig.
+func NewLoggerWithLevel(level zap.AtomicLevel, environment string) (*zap.Logger, error) {
+	return NewLoggerFromConfig(level, config.LoggerConfig{}, environment, "")
+}
 
-	// Build and return the logger
-	l, err := config.Build()
+// NewLoggerFromConfig creates a logger with the encoder, outputs, sampling and redaction of the logger config.
+// Unset settings follow the environment: JSON logs sampled at 100/100 per second in prd, colored
+// console logs otherwise, colored when written to a terminal. appName is the default syslog tag.
+// Close releases the log files and syslog connections of the logger.
+func NewLoggerFromConfig(level zap.AtomicLevel, cfg config.LoggerConfig, environment, appName string) (*zap.Logger, error) {
+	encoder, err := newEncoder(cfg, environment, isTerminal(cfg.Outputs))
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+	redactor, err := RedactorFromConfig(cfg.Redaction)
+	if err != nil {
+		return nil, err
+	}
+	sink, closer, err := newSink(cfg.Outputs, appName)
+	if err != nil {
+		return nil, err
 	}
-	defer l.Sync()
 
-	return l, nil
+	// The core accepts every level, the level is checked by levelCore so modules can have their own, see Levels
+	core := redactor.Wrap(zapcore.NewCore(encoder, sink, zapcore.DebugLevel))
+	core = newSampledCore(core, cfg.Sampling, environment)
+	core = &levelCore{Core: core, enabler: level, closer: closer}
+	opts := []zap.Option{
+		zap.AddCaller(),
+		zap.ErrorOutput(zapcore.Lock(os.Stderr)), // Errors of the logger itself
+	}
+	if environment == "prd" {
+		opts = append(opts, zap.AddStacktrace(zapcore.ErrorLevel))
+	} else {
+		opts = append(opts, zap.Development(), zap.AddStacktrace(zapcore.WarnLevel))
+	}
+	return zap.New(core, opts...), nil
+}
+
+// Close flushes a logger created by NewLoggerFromConfig, or derived from it, and closes its log files
+// and syslog connections. Every logger sharing the outputs must be done logging; other loggers are
+// only flushed.
+func Close(l *zap.Logger) error {
+	// Syncing stdout fails on terminals and pipes, which is not worth reporting
+	_ = l.Sync()
+	if lc, ok := l.Core().(*levelCore); ok && lc.closer != nil {
+		return lc.closer.Close()
+	}
+	return nil
 }
 
 // InitServiceLogger creates a base logger and attaches a service-specific field
 func InitServiceLogger(cfg *config.AppConfig) (*zap.Logger, error) {
-	logLevel, err := mapLogLevel(cfg.Logger.LogLevel)
+	logger, _, err := InitServiceLoggerWithLevel(cfg)
+	return logger, err
+}
+
+// InitServiceLoggerWithLevel is InitServiceLogger also returning the levels of the logger, which
+// change the level of every logger derived from it and of its modules at runtime.
+func InitServiceLoggerWithLevel(cfg *config.AppConfig) (*zap.Logger, *Levels, error) {
+	logLevel, err := ParseLevel(cfg.Logger.LogLevel)
 	if err != nil {
-		return nil, fmt.Errorf("failed to set log level: %w", err)
+		return nil, nil, fmt.Errorf("failed to set log level: %w", err)
 	}
 	// Create the logger based on environment and log level
-	logger, err := NewLogger(logLevel, cfg.App.Env)
+	levels := NewLevels(zap.NewAtomicLevelAt(logLevel))
+	if err := levels.SetModuleLevels(cfg.Logger.Modules); err != nil {
+		return nil, nil, err
+	}
+	logger, err := NewLoggerFromConfig(levels.Root(), cfg.Logger, cfg.App.Env, cfg.App.Name)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Add the service name as a field for every log entry
-	return logger.With(zap.String("service", cfg.App.Name)), nil
+	return logger.With(zap.String("service", cfg.App.Name)), levels, nil
 }
 
-// mapLogLevel maps a string log level to zapcore.Level
-func mapLogLevel(logLevel string) (zapcore.Level, error) {
+// ParseLevel maps a string log level (debug, info, warn, error, dpanic, panic, fatal) to zapcore.Level
+func ParseLevel(logLevel string) (zapcore.Level, error) {
 	switch strings.ToLower(logLevel) {
 	case "debug":
 		return zapcore.DebugLevel, nil
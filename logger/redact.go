@@ -0,0 +1,363 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/neodata-io/neodata-go/config"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// RedactionRule selects the values to hide from the logs by the key they are logged under.
+type RedactionRule struct {
+	Key     string // Case-insensitive regular expression matched against the keys, e.g. token matches access_token
+	Partial bool   // Mask part of the value instead of replacing it, e.g. j***@example.com
+}
+
+// DefaultRedactionRules returns the rules applied unless redaction is disabled: passwords,
+// tokens, secrets, authorization headers, cookies and API keys are replaced, emails are masked.
+func DefaultRedactionRules() []RedactionRule {
+	return []RedactionRule{
+		{Key: `passw(or)?d`},
+		{Key: `token`},
+		{Key: `secret`},
+		{Key: `authorization`},
+		{Key: `cookie`},
+		{Key: `api[-_]?key`},
+		{Key: `e-?mail`, Partial: true},
+	}
+}
+
+// Redactor hides sensitive values from the logs, see RedactionRule. A nil Redactor leaves every value as is.
+type Redactor struct {
+	rules []redactionRule
+}
+
+type redactionRule struct {
+	key     *regexp.Regexp
+	partial bool
+}
+
+// NewRedactor compiles the rules.
+func NewRedactor(rules []RedactionRule) (*Redactor, error) {
+	redactor := &Redactor{rules: make([]redactionRule, 0, len(rules))}
+	for _, rule := range rules {
+		key, err := regexp.Compile("(?i)" + rule.Key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction key pattern %q: %w", rule.Key, err)
+		}
+		redactor.rules = append(redactor.rules, redactionRule{key: key, partial: rule.Partial})
+	}
+	return redactor, nil
+}
+
+// RedactorFromConfig returns the redactor of logger.redaction: the default rules followed by the
+// configured ones, nil if redaction is disabled.
+func RedactorFromConfig(cfg config.LogRedactionConfig) (*Redactor, error) {
+	if cfg.Disabled {
+		return nil, nil
+	}
+	rules := DefaultRedactionRules()
+	for _, key := range cfg.Keys {
+		rules = append(rules, RedactionRule{Key: key})
+	}
+	for _, key := range cfg.Partial {
+		rules = append(rules, RedactionRule{Key: key, Partial: true})
+	}
+	return NewRedactor(rules)
+}
+
+var (
+	defaultRedactor     *Redactor
+	defaultRedactorOnce sync.Once
+)
+
+// DefaultRedactor returns the redactor of the default rules.
+func DefaultRedactor() *Redactor {
+	defaultRedactorOnce.Do(func() {
+		// The default patterns are valid
+		defaultRedactor, _ = NewRedactor(DefaultRedactionRules())
+	})
+	return defaultRedactor
+}
+
+// match returns the rule of a key, nil if its value is not sensitive.
+func (r *Redactor) match(key string) *redactionRule {
+	if r == nil {
+		return nil
+	}
+	for i := range r.rules {
+		if r.rules[i].key.MatchString(key) {
+			return &r.rules[i]
+		}
+	}
+	return nil
+}
+
+// RedactValue returns the value logged under key, redacted if the key matches a rule.
+func (r *Redactor) RedactValue(key, value string) string {
+	rule := r.match(key)
+	if rule == nil || value == "" {
+		return value
+	}
+	if rule.partial {
+		return maskPartial(value)
+	}
+	return config.RedactedValue
+}
+
+// RedactHeaders returns the headers with the values of sensitive ones redacted.
+func (r *Redactor) RedactHeaders(headers map[string][]string) map[string][]string {
+	redacted := make(map[string][]string, len(headers))
+	for key, values := range headers {
+		redactedValues := make([]string, len(values))
+		for i, value := range values {
+			redactedValues[i] = r.RedactValue(key, value)
+		}
+		redacted[key] = redactedValues
+	}
+	return redacted
+}
+
+// RedactQuery returns a query string, or a form body, with the values of sensitive parameters redacted.
+// The other parameters are kept as they are, in their order.
+func (r *Redactor) RedactQuery(query string) string {
+	if r == nil || query == "" {
+		return query
+	}
+	params := strings.Split(query, "&")
+	for i, param := range params {
+		rawKey, value, ok := strings.Cut(param, "=")
+		if !ok {
+			continue
+		}
+		key, err := url.QueryUnescape(rawKey)
+		if err != nil {
+			key = rawKey
+		}
+		if r.match(key) == nil {
+			continue
+		}
+		if unescaped, err := url.QueryUnescape(value); err == nil {
+			value = unescaped
+		}
+		params[i] = rawKey + "=" + r.RedactValue(key, value)
+	}
+	return strings.Join(params, "&")
+}
+
+// RedactJSON returns a JSON document with the values of sensitive keys redacted at any depth.
+// Documents that cannot be parsed are returned as is.
+func (r *Redactor) RedactJSON(data []byte) []byte {
+	if r == nil || len(data) == 0 {
+		return data
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber() // Numbers are written back unchanged
+	var document any
+	if err := decoder.Decode(&document); err != nil {
+		return data
+	}
+	redacted, changed := r.redactAny(document)
+	if !changed {
+		return data
+	}
+	out, err := json.Marshal(redacted)
+	if err != nil {
+		return data
+	}
+	return out
+}
+
+// RedactBody returns a request or response body with sensitive values redacted, for JSON and form bodies.
+// Other bodies are returned as is.
+func (r *Redactor) RedactBody(contentType string, body []byte) []byte {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/json", strings.HasSuffix(mediaType, "+json"):
+		return r.RedactJSON(body)
+	case mediaType == "application/x-www-form-urlencoded":
+		return []byte(r.RedactQuery(string(body)))
+	default:
+		return body
+	}
+}
+
+// redactAny redacts the values of sensitive keys in maps and slices, reporting whether any was.
+func (r *Redactor) redactAny(value any) (any, bool) {
+	switch v := value.(type) {
+	case map[string]any:
+		changed := false
+		for key, item := range v {
+			if rule := r.match(key); rule != nil {
+				v[key] = redactGeneric(rule, item)
+				changed = true
+				continue
+			}
+			if redacted, itemChanged := r.redactAny(item); itemChanged {
+				v[key] = redacted
+				changed = true
+			}
+		}
+		return v, changed
+	case []any:
+		changed := false
+		for i, item := range v {
+			if redacted, itemChanged := r.redactAny(item); itemChanged {
+				v[i] = redacted
+				changed = true
+			}
+		}
+		return v, changed
+	default:
+		return value, false
+	}
+}
+
+// redactGeneric redacts a decoded value, partial masks only apply to strings.
+func redactGeneric(rule *redactionRule, value any) any {
+	s, ok := value.(string)
+	switch {
+	case ok && s == "":
+		return s
+	case ok && rule.partial:
+		return maskPartial(s)
+	default:
+		return config.RedactedValue
+	}
+}
+
+// maskPartial masks a value, keeping the first character of emails and their domain, and the
+// first and last characters of other values.
+func maskPartial(value string) string {
+	if at := strings.LastIndex(value, "@"); at > 0 {
+		return value[:1] + "***" + value[at:]
+	}
+	if len(value) <= 4 {
+		return "***"
+	}
+	return value[:1] + "***" + value[len(value)-1:]
+}
+
+// Wrap returns a core redacting the fields logged through core, including the fields of nested
+// objects. Numbers, booleans, durations and times are never sensitive and are logged as is.
+func (r *Redactor) Wrap(core zapcore.Core) zapcore.Core {
+	if r == nil || len(r.rules) == 0 {
+		return core
+	}
+	return &redactCore{Core: core, redactor: r}
+}
+
+// redactCore implements the redaction of a Redactor.
+type redactCore struct {
+	zapcore.Core
+	redactor *Redactor
+}
+
+func (c *redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactCore{Core: c.Core.With(c.redactor.redactFields(fields)), redactor: c.redactor}
+}
+
+func (c *redactCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *redactCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(entry, c.redactor.redactFields(fields))
+}
+
+// redactFields returns the fields with sensitive values redacted, fields itself if none is.
+func (r *Redactor) redactFields(fields []zapcore.Field) []zapcore.Field {
+	var redacted []zapcore.Field
+	for i, field := range fields {
+		replacement, changed := r.redactField(field)
+		if !changed {
+			continue
+		}
+		if redacted == nil {
+			redacted = append(make([]zapcore.Field, 0, len(fields)), fields...)
+		}
+		redacted[i] = replacement
+	}
+	if redacted == nil {
+		return fields
+	}
+	return redacted
+}
+
+// redactField redacts a field by its key, or the sensitive keys of an object field.
+func (r *Redactor) redactField(field zapcore.Field) (zapcore.Field, bool) {
+	switch field.Type {
+	case zapcore.NamespaceType, zapcore.SkipType, zapcore.ErrorType,
+		zapcore.BoolType, zapcore.DurationType, zapcore.TimeType, zapcore.TimeFullType,
+		zapcore.Int64Type, zapcore.Int32Type, zapcore.Int16Type, zapcore.Int8Type,
+		zapcore.Uint64Type, zapcore.Uint32Type, zapcore.Uint16Type, zapcore.Uint8Type, zapcore.UintptrType,
+		zapcore.Float64Type, zapcore.Float32Type, zapcore.Complex128Type, zapcore.Complex64Type:
+		return field, false
+	}
+
+	if rule := r.match(field.Key); rule != nil {
+		if rule.partial && field.Type == zapcore.StringType {
+			return zap.String(field.Key, maskPartial(field.String)), true
+		}
+		return zap.String(field.Key, config.RedactedValue), true
+	}
+
+	switch field.Type {
+	case zapcore.ObjectMarshalerType, zapcore.InlineMarshalerType, zapcore.ArrayMarshalerType:
+		// Encoded to maps and slices, as decoded JSON, to find the keys of the object
+		enc := zapcore.NewMapObjectEncoder()
+		field.AddTo(enc)
+		if field.Type == zapcore.InlineMarshalerType {
+			redacted, changed := r.redactAny(enc.Fields)
+			if !changed {
+				return field, false
+			}
+			return zap.Inline(redactedObject(redacted.(map[string]any))), true
+		}
+		redacted, changed := r.redactAny(enc.Fields[field.Key])
+		if !changed {
+			return field, false
+		}
+		return zap.Any(field.Key, redacted), true
+	case zapcore.ReflectType:
+		data, err := json.Marshal(field.Interface)
+		if err != nil {
+			return field, false
+		}
+		var document any
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&document); err != nil {
+			return field, false
+		}
+		redacted, changed := r.redactAny(document)
+		if !changed {
+			return field, false
+		}
+		return zap.Any(field.Key, redacted), true
+	}
+	return field, false
+}
+
+// redactedObject logs the redacted fields of an inlined object.
+type redactedObject map[string]any
+
+func (o redactedObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for key, value := range o {
+		if err := enc.AddReflected(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
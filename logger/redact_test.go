@@ -0,0 +1,103 @@
+package logger
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/neodata-io/neodata-go/config"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRedactValue(t *testing.T) {
+	redactor := DefaultRedactor()
+	for _, tt := range []struct {
+		key, value, want string
+	}{
+		{key: "password", value: "hunter2", want: config.RedactedValue},
+		{key: "access_token", value: "abc", want: config.RedactedValue},
+		{key: "X-API-Key", value: "k123", want: config.RedactedValue},
+		{key: "Authorization", value: "Bearer abc", want: config.RedactedValue},
+		{key: "email", value: "john.doe@example.com", want: maskPartial("john.doe@example.com")},
+		{key: "order_id", value: "42", want: "42"},
+		{key: "password", value: "", want: ""},
+	} {
+		if got := redactor.RedactValue(tt.key, tt.value); got != tt.want {
+			t.Errorf("RedactValue(%q, %q) = %q, want %q", tt.key, tt.value, got, tt.want)
+		}
+	}
+	if got := maskPartial("john.doe@example.com"); strings.Contains(got, "john.doe") || !strings.HasSuffix(got, "@example.com") {
+		t.Errorf("maskPartial() = %q, want the local part masked", got)
+	}
+
+	var disabled *Redactor
+	if got := disabled.RedactValue("password", "hunter2"); got != "hunter2" {
+		t.Errorf("nil Redactor RedactValue() = %q, want the value as is", got)
+	}
+}
+
+func TestRedactBodyAndQuery(t *testing.T) {
+	redactor := DefaultRedactor()
+
+	body := string(redactor.RedactBody("application/json", []byte(`{"user":"u","password":"hunter2","nested":{"token":"t1"}}`)))
+	if strings.Contains(body, "hunter2") || strings.Contains(body, "t1") || !strings.Contains(body, `"user":"u"`) {
+		t.Errorf("RedactBody(json) = %s, want password and token redacted", body)
+	}
+
+	form := string(redactor.RedactBody("application/x-www-form-urlencoded", []byte("password=hunter2&name=jane")))
+	if strings.Contains(form, "hunter2") || !strings.Contains(form, "name=jane") {
+		t.Errorf("RedactBody(form) = %s, want password redacted", form)
+	}
+
+	query := redactor.RedactQuery("token=t1&q=orders")
+	if strings.Contains(query, "t1") || !strings.Contains(query, "q=orders") {
+		t.Errorf("RedactQuery() = %s, want token redacted", query)
+	}
+}
+
+func TestRedactorFromConfig(t *testing.T) {
+	redactor, err := RedactorFromConfig(config.LogRedactionConfig{Keys: []string{"ssn"}, Partial: []string{"phone"}})
+	if err != nil {
+		t.Fatalf("RedactorFromConfig() error = %v", err)
+	}
+	if got := redactor.RedactValue("customer_ssn", "123"); got != config.RedactedValue {
+		t.Errorf("RedactValue(customer_ssn) = %q, want it redacted", got)
+	}
+	if got := redactor.RedactValue("password", "hunter2"); got != config.RedactedValue {
+		t.Errorf("RedactValue(password) = %q, want the default rules kept", got)
+	}
+
+	if redactor, err := RedactorFromConfig(config.LogRedactionConfig{Disabled: true}); err != nil || redactor != nil {
+		t.Errorf("RedactorFromConfig(disabled) = %v, %v, want nil", redactor, err)
+	}
+	if _, err := RedactorFromConfig(config.LogRedactionConfig{Keys: []string{"("}}); err == nil {
+		t.Error("RedactorFromConfig() with an invalid pattern succeeded")
+	}
+}
+
+func TestRedactCore(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+	log := zap.New(DefaultRedactor().Wrap(core))
+
+	log.With(zap.String("access_token", "abc")).Info("Login",
+		zap.String("password", "hunter2"),
+		zap.Dict("user", zap.String("secret", "s"), zap.String("name", "jane")),
+		zap.String("order_id", "42"),
+	)
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("logged %d entries, want 1", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	if fields["access_token"] != config.RedactedValue || fields["password"] != config.RedactedValue {
+		t.Errorf("fields = %v, want access_token and password redacted", fields)
+	}
+	if fields["order_id"] != "42" {
+		t.Errorf("order_id = %v, want 42", fields["order_id"])
+	}
+	user, _ := fields["user"].(map[string]interface{})
+	if user["secret"] != config.RedactedValue || user["name"] != "jane" {
+		t.Errorf("user = %v, want the nested secret redacted", fields["user"])
+	}
+}
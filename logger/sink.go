@@ -0,0 +1,140 @@
+package logger
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/neodata-io/neodata-go/config"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// Types of the log outputs.
+const (
+	OutputStdout = "stdout"
+	OutputStderr = "stderr"
+	OutputFile   = "file"
+	OutputSyslog = "syslog"
+)
+
+// defaultMaxSize is the size in megabytes of a log file before it is rotated.
+const defaultMaxSize = 100
+
+// closers closes the files and connections of the outputs, see Close.
+type closers []io.Closer
+
+func (c closers) Close() error {
+	var errs []error
+	for _, closer := range c {
+		errs = append(errs, closer.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// newSink opens the outputs of the logs, stdout if there are none. The returned closer closes
+// the files and syslog connections.
+func newSink(outputs []config.LogOutputConfig, appName string) (zapcore.WriteSyncer, io.Closer, error) {
+	if len(outputs) == 0 {
+		return zapcore.Lock(os.Stdout), closers(nil), nil
+	}
+
+	syncers := make([]zapcore.WriteSyncer, 0, len(outputs))
+	var opened closers
+	for i, output := range outputs {
+		syncer, closer, err := newOutput(output, appName)
+		if err != nil {
+			// Release the outputs already opened
+			return nil, nil, errors.Join(fmt.Errorf("failed to open log output %d (%s): %w", i, output.Type, err), opened.Close())
+		}
+		syncers = append(syncers, syncer)
+		if closer != nil {
+			opened = append(opened, closer)
+		}
+	}
+	return zapcore.NewMultiWriteSyncer(syncers...), opened, nil
+}
+
+// newOutput opens a single output, and returns its closer, nil for stdout and stderr.
+func newOutput(output config.LogOutputConfig, appName string) (zapcore.WriteSyncer, io.Closer, error) {
+	switch output.Type {
+	case OutputStdout:
+		return zapcore.Lock(os.Stdout), nil, nil
+	case OutputStderr:
+		return zapcore.Lock(os.Stderr), nil, nil
+	case OutputFile:
+		if output.Path == "" {
+			return nil, nil, fmt.Errorf("path is required")
+		}
+		maxSize := output.MaxSize
+		if maxSize == 0 {
+			maxSize = defaultMaxSize
+		}
+		// lumberjack is safe for concurrent use and rotates the file once it reaches MaxSize
+		file := &lumberjack.Logger{
+			Filename:   output.Path,
+			MaxSize:    maxSize,
+			MaxBackups: output.MaxBackups,
+			MaxAge:     output.MaxAge,
+			Compress:   output.Compress,
+		}
+		return zapcore.AddSync(file), file, nil
+	case OutputSyslog:
+		tag := output.Tag
+		if tag == "" {
+			tag = appName
+		}
+		writer, err := dialSyslog(output.Network, output.Address, tag)
+		if err != nil {
+			return nil, nil, err
+		}
+		return zapcore.AddSync(writer), writer, nil
+	default:
+		return nil, nil, fmt.Errorf("invalid log output type: %s", output.Type)
+	}
+}
+
+// isTerminal reports whether every output is a terminal, the only outputs where the console
+// encoder may use colors: ANSI codes would end up in files, syslog and log collectors.
+func isTerminal(outputs []config.LogOutputConfig) bool {
+	if len(outputs) == 0 {
+		return isCharDevice(os.Stdout)
+	}
+	for _, output := range outputs {
+		switch {
+		case output.Type == OutputStdout && isCharDevice(os.Stdout):
+		case output.Type == OutputStderr && isCharDevice(os.Stderr):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// isCharDevice reports whether file is a terminal.
+func isCharDevice(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// newSampledCore wraps core with the sampling of the config, or the default of the environment.
+func newSampledCore(core zapcore.Core, sampling *config.LogSamplingConfig, environment string) zapcore.Core {
+	if sampling == nil {
+		if environment != "prd" {
+			return core
+		}
+		// The sampling of zap's production config
+		sampling = &config.LogSamplingConfig{Initial: 100, Thereafter: 100}
+	}
+	if sampling.Initial == 0 && sampling.Thereafter == 0 {
+		return core
+	}
+
+	tick := sampling.Tick * time.Second
+	if tick == 0 {
+		tick = time.Second
+	}
+	return zapcore.NewSamplerWithOptions(core, tick, sampling.Initial, sampling.Thereafter)
+}
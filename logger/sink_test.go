@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/neodata-io/neodata-go/config"
+	"go.uber.org/zap"
+)
+
+// openFiles counts the file descriptors of the process open on path.
+func openFiles(t *testing.T, path string) int {
+	t.Helper()
+	fds, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		t.Skip("open files cannot be listed on this platform")
+	}
+	count := 0
+	for _, fd := range fds {
+		if target, err := os.Readlink(filepath.Join("/proc/self/fd", fd.Name())); err == nil && target == path {
+			count++
+		}
+	}
+	return count
+}
+
+func TestFileOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "service.log")
+	cfg := config.LoggerConfig{Outputs: []config.LogOutputConfig{{Type: OutputFile, Path: path}}}
+	levels := NewLevels(zap.NewAtomicLevelAt(zap.InfoLevel))
+	log, err := NewLoggerFromConfig(levels.Root(), cfg, "dev", "test")
+	if err != nil {
+		t.Fatalf("NewLoggerFromConfig() error = %v", err)
+	}
+
+	// Loggers derived from the root one share its outputs and close them too
+	derived := levels.Named(log.With(zap.String("service", "test")), ModuleDB)
+	derived.Warn("Slow query")
+	if n := openFiles(t, path); n != 1 {
+		t.Fatalf("%d open log files, want 1", n)
+	}
+	if err := Close(derived); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if n := openFiles(t, path); n != 0 {
+		t.Errorf("%d open log files after Close, want 0", n)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if !strings.Contains(string(data), "WARN") || !strings.Contains(string(data), "Slow query") {
+		t.Errorf("log file = %q, want the warning", data)
+	}
+	if strings.Contains(string(data), "\x1b[") {
+		t.Errorf("log file = %q contains ANSI color codes", data)
+	}
+}
+
+func TestInvalidOutputClosesOpenedOutputs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "service.log")
+	outputs := []config.LogOutputConfig{{Type: OutputFile, Path: path}, {Type: "kafka"}}
+	if _, _, err := newSink(outputs, "test"); err == nil {
+		t.Fatal("newSink() error = nil, want invalid log output type")
+	}
+	if n := openFiles(t, path); n != 0 {
+		t.Errorf("%d open log files, want 0", n)
+	}
+}
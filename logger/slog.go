@@ -0,0 +1,140 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewSlogHandler returns a slog.Handler logging through l, so libraries using log/slog log
+// through the service logger. Groups are nested objects, as with slog.JSONHandler. With a logger
+// returned by Wrap, the caller is the code calling slog rather than the handler.
+//
+//	slog.SetDefault(slog.New(logger.NewSlogHandler(logger.Wrap(log))))
+func NewSlogHandler(l Logger) slog.Handler {
+	return &slogHandler{logger: l}
+}
+
+// entryChecker is implemented by the loggers returned by Wrap, whose entries can be given the
+// caller recorded by slog.
+type entryChecker interface {
+	Check(level zapcore.Level, msg string) *zapcore.CheckedEntry
+}
+
+// slogHandler implements slog.Handler with a Logger.
+type slogHandler struct {
+	logger Logger
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return Enabled(h.logger, slogLevel(level))
+}
+
+func (h *slogHandler) Handle(ctx context.Context, record slog.Record) error {
+	fields := make([]zap.Field, 0, record.NumAttrs()+2)
+	record.Attrs(func(attr slog.Attr) bool {
+		fields = appendAttr(fields, attr)
+		return true
+	})
+	fields = append(fields, TraceFields(ctx)...)
+
+	if checker, ok := h.logger.(entryChecker); ok && record.PC != 0 {
+		if entry := checker.Check(slogLevel(record.Level), record.Message); entry != nil {
+			if entry.Caller.Defined {
+				frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+				entry.Caller = zapcore.NewEntryCaller(frame.PC, frame.File, frame.Line, true)
+			}
+			entry.Write(fields...)
+		}
+		return nil
+	}
+
+	switch slogLevel(record.Level) {
+	case zapcore.DebugLevel:
+		h.logger.Debug(record.Message, fields...)
+	case zapcore.InfoLevel:
+		h.logger.Info(record.Message, fields...)
+	case zapcore.WarnLevel:
+		h.logger.Warn(record.Message, fields...)
+	default:
+		h.logger.Error(record.Message, fields...)
+	}
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := make([]zap.Field, 0, len(attrs))
+	for _, attr := range attrs {
+		fields = appendAttr(fields, attr)
+	}
+	return &slogHandler{logger: h.logger.With(fields...)}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	// Every later field is nested in the namespace, the slog semantics of groups
+	return &slogHandler{logger: h.logger.With(zap.Namespace(name))}
+}
+
+// slogLevel maps a slog level to the zap level logging it.
+func slogLevel(level slog.Level) zapcore.Level {
+	switch {
+	case level < slog.LevelInfo:
+		return zapcore.DebugLevel
+	case level < slog.LevelWarn:
+		return zapcore.InfoLevel
+	case level < slog.LevelError:
+		return zapcore.WarnLevel
+	default:
+		return zapcore.ErrorLevel
+	}
+}
+
+// appendAttr appends the field of a slog attribute, skipping empty ones as slog handlers do.
+func appendAttr(fields []zap.Field, attr slog.Attr) []zap.Field {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	switch attr.Value.Kind() {
+	case slog.KindGroup:
+		attrs := attr.Value.Group()
+		if len(attrs) == 0 {
+			return fields
+		}
+		group := make([]zap.Field, 0, len(attrs))
+		for _, groupAttr := range attrs {
+			group = appendAttr(group, groupAttr)
+		}
+		if attr.Key == "" {
+			// Inlined, see slog.Group
+			return append(fields, group...)
+		}
+		return append(fields, zap.Dict(attr.Key, group...))
+	case slog.KindString:
+		return append(fields, zap.String(attr.Key, attr.Value.String()))
+	case slog.KindInt64:
+		return append(fields, zap.Int64(attr.Key, attr.Value.Int64()))
+	case slog.KindUint64:
+		return append(fields, zap.Uint64(attr.Key, attr.Value.Uint64()))
+	case slog.KindFloat64:
+		return append(fields, zap.Float64(attr.Key, attr.Value.Float64()))
+	case slog.KindBool:
+		return append(fields, zap.Bool(attr.Key, attr.Value.Bool()))
+	case slog.KindDuration:
+		return append(fields, zap.Duration(attr.Key, attr.Value.Duration()))
+	case slog.KindTime:
+		return append(fields, zap.Time(attr.Key, attr.Value.Time()))
+	default:
+		if err, ok := attr.Value.Any().(error); ok {
+			return append(fields, zap.NamedError(attr.Key, err))
+		}
+		return append(fields, zap.Any(attr.Key, attr.Value.Any()))
+	}
+}
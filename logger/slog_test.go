@@ -0,0 +1,31 @@
+package logger
+
+import (
+	"log/slog"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSlogHandlerCaller(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	log := slog.New(NewSlogHandler(Wrap(zap.New(core, zap.AddCaller()))))
+
+	log.Info("Started", "port", 8080)
+	log.With("module", "test").WithGroup("request").Warn("Slow")
+
+	entries := logs.All()
+	if len(entries) != 2 {
+		t.Fatalf("logged %d entries, want 2", len(entries))
+	}
+	for _, entry := range entries {
+		if file := filepath.Base(entry.Caller.File); file != "slog_test.go" {
+			t.Errorf("caller of %q = %s, want slog_test.go", entry.Message, entry.Caller.File)
+		}
+	}
+	if port := entries[0].ContextMap()["port"]; port != int64(8080) {
+		t.Errorf("port = %v, want 8080", port)
+	}
+}
@@ -0,0 +1,13 @@
+//go:build windows || plan9
+
+package logger
+
+import (
+	"errors"
+	"io"
+)
+
+// dialSyslog is not supported on this platform.
+func dialSyslog(network, address, tag string) (io.WriteCloser, error) {
+	return nil, errors.New("syslog is not supported on this platform")
+}
@@ -0,0 +1,14 @@
+//go:build !windows && !plan9
+
+package logger
+
+import (
+	"io"
+	"log/syslog"
+)
+
+// dialSyslog connects to a syslog daemon, the local one if network is empty.
+// Entries are sent with the info priority, their level is part of the encoded entry.
+func dialSyslog(network, address, tag string) (io.WriteCloser, error) {
+	return syslog.Dial(network, address, syslog.LOG_INFO|syslog.LOG_USER, tag)
+}
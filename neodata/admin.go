@@ -0,0 +1,145 @@
+package neodata
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/neodata-io/neodata-go/config"
+	"github.com/neodata-io/neodata-go/errors"
+	"github.com/neodata-io/neodata-go/logger"
+	"go.uber.org/zap"
+)
+
+// Paths of the admin endpoints when their option is given none.
+const (
+	DefaultConfigEndpoint   = "/admin/config"
+	DefaultLogLevelEndpoint = "/admin/log-level"
+)
+
+// ConfigReport is the response of the config endpoint.
+type ConfigReport struct {
+	Config   json.RawMessage `json:"config"`            // Effective configuration, secrets redacted
+	Sources  config.Sources  `json:"sources,omitempty"` // Layer each value came from
+	LoadedAt time.Time       `json:"loaded_at"`         // Startup or last reload
+}
+
+// WithConfigEndpoint serves the effective configuration, where each value came from and when it was
+// last reloaded on path, /admin/config by default. The endpoint requires authentication.
+func WithConfigEndpoint(path string) Option {
+	return requires(func(ctx *NeoCtx) error {
+		if _, err := ctx.GetHTTPServer(); err != nil {
+			return fmt.Errorf("config endpoint requires the HTTP server: %w", err)
+		}
+		if path == "" {
+			path = DefaultConfigEndpoint
+		}
+
+		NewRouter(ctx).GET(path, func(*RequestCtx) (interface{}, error) {
+			return ctx.ConfigReport()
+		}, Authenticated(), Summary("Effective configuration"), Tags("admin"))
+
+		ctx.Logger.Info("Config endpoint initialized", zap.String("path", path))
+		return nil
+	}, config.SectionAuth)
+}
+
+// ConfigReport returns the effective configuration with secrets redacted, the layer each value came
+// from when the config manager reports it, and when it was loaded.
+func (n *NeoCtx) ConfigReport() (*ConfigReport, error) {
+	dump, err := n.configManager.Dump(config.FormatJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dump configuration: %w", err)
+	}
+
+	report := &ConfigReport{Config: dump}
+	if manager, ok := n.configManager.(interface{ Sources() config.Sources }); ok {
+		report.Sources = manager.Sources()
+	}
+	if manager, ok := n.configManager.(interface{ LoadedAt() time.Time }); ok {
+		report.LoadedAt = manager.LoadedAt()
+	}
+	return report, nil
+}
+
+// LogLevelRequest changes the level of the logger, or of one of its modules.
+type LogLevelRequest struct {
+	Level  string `json:"level" validate:"omitempty,oneof=debug info warn error dpanic panic fatal"` // Empty makes the module follow the log level again
+	Module string `json:"module"`                                                                    // Empty for the log level, e.g. messaging
+}
+
+// LogLevelReport is the response of the log level endpoint.
+type LogLevelReport struct {
+	Level   string            `json:"level"`
+	Modules map[string]string `json:"modules"` // Effective level of every module
+}
+
+// WithLogLevelEndpoint serves the log levels on path, /admin/log-level by default, and changes them
+// with PUT until the next restart or change of logger.log_level or logger.modules. The endpoint
+// requires authentication.
+//
+//	curl -X PUT -H "Authorization: Bearer $TOKEN" -d '{"module":"messaging","level":"debug"}' localhost:8080/admin/log-level
+func WithLogLevelEndpoint(path string) Option {
+	return requires(func(ctx *NeoCtx) error {
+		if _, err := ctx.GetHTTPServer(); err != nil {
+			return fmt.Errorf("log level endpoint requires the HTTP server: %w", err)
+		}
+		if path == "" {
+			path = DefaultLogLevelEndpoint
+		}
+
+		router := NewRouter(ctx)
+		router.GET(path, func(*RequestCtx) (interface{}, error) {
+			return ctx.LogLevels(), nil
+		}, Authenticated(), Summary("Log levels"), Tags("admin"))
+		Handle(router, "PUT", path, func(reqCtx *RequestCtx, req LogLevelRequest) (*LogLevelReport, error) {
+			if err := ctx.SetLogLevel(req.Module, req.Level); err != nil {
+				return nil, err
+			}
+			reqCtx.Logger.Info("Log level changed", zap.String("module", req.Module), zap.String("level", req.Level))
+			return ctx.LogLevels(), nil
+		}, Authenticated(), Summary("Change a log level"), Tags("admin"), Errors(errors.ErrBadRequest))
+
+		ctx.Logger.Info("Log level endpoint initialized", zap.String("path", path))
+		return nil
+	}, config.SectionAuth)
+}
+
+// LogLevels returns the level of the logger and the effective level of its modules.
+func (n *NeoCtx) LogLevels() *LogLevelReport {
+	report := &LogLevelReport{
+		Level:   n.logLevels.Root().Level().String(),
+		Modules: make(map[string]string),
+	}
+	for module, level := range n.logLevels.ModuleLevels() {
+		report.Modules[module] = level.String()
+	}
+	return report
+}
+
+// SetLogLevel sets the level of the logger, or of a module when module is not empty.
+// An empty level makes the module follow the level of the logger again.
+func (n *NeoCtx) SetLogLevel(module, level string) error {
+	if module == "" && level == "" {
+		return errors.BadRequest("level is required")
+	}
+	if level == "" {
+		if err := n.logLevels.ResetModuleLevel(module); err != nil {
+			return errors.BadRequest(err.Error())
+		}
+		return nil
+	}
+
+	parsed, err := logger.ParseLevel(level)
+	if err != nil {
+		return errors.BadRequest(err.Error())
+	}
+	if module == "" {
+		n.logLevels.Root().SetLevel(parsed)
+		return nil
+	}
+	if err := n.logLevels.SetModuleLevel(module, parsed); err != nil {
+		return errors.BadRequest(err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package neodata
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/neodata-io/neodata-go/domain/entities"
+	"github.com/neodata-io/neodata-go/logger"
+	"go.uber.org/zap"
+)
+
+func TestLogLevelEndpoint(t *testing.T) {
+	app := newTestApp(t, WithHTTPServer(), WithLogLevelEndpoint(""))
+	srv, _ := app.Context.GetHTTPServer()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, entities.Claims{UserID: "admin"}).SignedString([]byte(app.Config.Auth.JwtSecret))
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+
+	call := func(method, body string, authenticated bool) (int, LogLevelReport) {
+		t.Helper()
+		req := httptest.NewRequest(method, DefaultLogLevelEndpoint, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if authenticated {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := srv.Test(req)
+		if err != nil {
+			t.Fatalf("Test() error = %v", err)
+		}
+		var report LogLevelReport
+		if resp.StatusCode == fiber.StatusOK {
+			if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+				t.Fatalf("decode report: %v", err)
+			}
+		}
+		return resp.StatusCode, report
+	}
+
+	if status, _ := call("GET", "", false); status != fiber.StatusUnauthorized {
+		t.Errorf("GET without a token = %d, want 401", status)
+	}
+	if status, _ := call("PUT", `{"level":"debug"}`, false); status != fiber.StatusUnauthorized {
+		t.Errorf("PUT without a token = %d, want 401", status)
+	}
+
+	status, report := call("GET", "", true)
+	if status != fiber.StatusOK || report.Level != "error" || report.Modules[logger.ModuleMessaging] != "error" {
+		t.Errorf("GET = %d %+v, want 200 with every level at error", status, report)
+	}
+
+	status, report = call("PUT", `{"module":"messaging","level":"debug"}`, true)
+	if status != fiber.StatusOK || report.Level != "error" || report.Modules[logger.ModuleMessaging] != "debug" || report.Modules[logger.ModuleDB] != "error" {
+		t.Errorf("PUT messaging = %d %+v, want 200 with messaging at debug", status, report)
+	}
+	status, report = call("PUT", `{"level":"warn"}`, true)
+	if status != fiber.StatusOK || report.Level != "warn" || report.Modules[logger.ModuleDB] != "warn" || report.Modules[logger.ModuleMessaging] != "debug" {
+		t.Errorf("PUT root = %d %+v, want 200 with the root level at warn", status, report)
+	}
+	if !app.Logger.Core().Enabled(zap.WarnLevel) || app.Logger.Core().Enabled(zap.InfoLevel) {
+		t.Error("logger of the app does not follow the new root level")
+	}
+	status, report = call("PUT", `{"module":"messaging"}`, true)
+	if status != fiber.StatusOK || report.Modules[logger.ModuleMessaging] != "warn" {
+		t.Errorf("PUT reset = %d %+v, want 200 with messaging following the root level", status, report)
+	}
+
+	for _, body := range []string{`{}`, `{"level":"verbose"}`, `{"module":"kafka","level":"debug"}`, `{"module":"kafka"}`} {
+		if status, _ := call("PUT", body, true); status != fiber.StatusBadRequest {
+			t.Errorf("PUT %s = %d, want 400", body, status)
+		}
+	}
+}
@@ -2,145 +2,459 @@ package neodata
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
+	"github.com/gofiber/fiber/v3"
 	"github.com/neodata-io/neodata-go/config"
 	"github.com/neodata-io/neodata-go/infrastructure/auth/policy"
+	"github.com/neodata-io/neodata-go/infrastructure/cache"
 	"github.com/neodata-io/neodata-go/infrastructure/db/postgres"
 	"github.com/neodata-io/neodata-go/infrastructure/messaging"
+	tracing "github.com/neodata-io/neodata-go/infrastructure/observability"
 	"github.com/neodata-io/neodata-go/infrastructure/transport/http"
 	"github.com/neodata-io/neodata-go/logger"
 
 	"go.uber.org/zap"
 )
 
+// defaultConfigPath is used when no config option is passed to New.
+const defaultConfigPath = "./config/config.yaml"
+
 // App manages the lifecycle and high-level initialization of the application.
 type App struct {
-	Config  *config.AppConfig // Application configuration
-	Logger  *zap.Logger       // Application logger
-	Context *NeoCtx           // Neo context for scoped services
+	Config        *config.AppConfig    // Application configuration
+	ConfigManager config.ConfigManager // Source the configuration was loaded from
+	Logger        *zap.Logger          // Application logger
+	Context       *NeoCtx              // Neo context for scoped services
+
+	httpShutdown sync.Once // The HTTP server is shut down by the drain or by Shutdown, whichever comes first
 }
 
 // Option defines a function that modifies the Neo Context
 type Option func(*NeoCtx) error
 
-// New initializes the application with options for services
+// requires declares the config sections an option needs. They are validated, every invalid key
+// reported at once, before the option is applied, and watched for changes while the app runs.
+func requires(option Option, sections ...string) Option {
+	return func(ctx *NeoCtx) error {
+		if err := ctx.requireSections(sections...); err != nil {
+			return err
+		}
+		return option(ctx)
+	}
+}
+
+// configSource collects the config options before the configuration is loaded.
+type configSource struct {
+	opts    config.LoadOptions
+	manager config.ConfigManager
+}
+
+// ConfigOption selects where NewWithConfig loads the configuration from.
+// Config options run before the logger and NeoCtx are built.
+type ConfigOption func(*configSource) error
+
+// WithConfigFile loads the configuration from the given file instead of ./config/config.yaml.
+func WithConfigFile(path string) ConfigOption {
+	return func(src *configSource) error {
+		if path == "" {
+			return fmt.Errorf("config file path is empty")
+		}
+		src.opts.ConfigPath = path
+		src.manager = nil
+		return nil
+	}
+}
+
+// WithConfigOptions loads the layered configuration with the given options, e.g. to apply
+// command line flags registered with config.RegisterFlags or to change the env var prefix.
+func WithConfigOptions(opts config.LoadOptions) ConfigOption {
+	return func(src *configSource) error {
+		if opts.ConfigPath == "" {
+			opts.ConfigPath = src.opts.ConfigPath
+		}
+		src.opts = opts
+		src.manager = nil
+		return nil
+	}
+}
+
+// WithConfigManager uses an existing ConfigManager as the configuration source.
+func WithConfigManager(manager config.ConfigManager) ConfigOption {
+	return func(src *configSource) error {
+		if manager == nil {
+			return fmt.Errorf("config manager is nil")
+		}
+		src.manager = manager
+		return nil
+	}
+}
+
+// WithAppConfig uses a configuration built in code, e.g. inside tests.
+func WithAppConfig(cfg *config.AppConfig) ConfigOption {
+	return func(src *configSource) error {
+		if cfg == nil {
+			return fmt.Errorf("app config is nil")
+		}
+		src.manager = config.NewStaticConfigManager(cfg)
+		return nil
+	}
+}
+
+// load returns the selected config manager, reading the config file if none was injected.
+func (src *configSource) load() (config.ConfigManager, error) {
+	if src.manager != nil {
+		return src.manager, nil
+	}
+	return config.NewConfigManagerWithOptions(src.opts)
+}
+
+// New initializes the application with options for services, loading the configuration from
+// ./config/config.yaml.
 func New(options ...Option) (*App, error) {
+	return NewWithConfig(nil, options...)
+}
+
+// NewWithConfig initializes the application with options for services, loading the configuration
+// from the source selected by configOptions.
+//
+//	app, err := neodata.NewWithConfig([]neodata.ConfigOption{neodata.WithAppConfig(cfg)}, neodata.WithHTTPServer())
+func NewWithConfig(configOptions []ConfigOption, options ...Option) (*App, error) {
+	// Resolve the configuration source before anything depends on it
+	src := &configSource{opts: config.LoadOptions{ConfigPath: defaultConfigPath}}
+	for _, configOption := range configOptions {
+		if err := configOption(src); err != nil {
+			return nil, fmt.Errorf("failed to apply config option: %w", err)
+		}
+	}
+
 	// Load Configuration
-	cfgManager, err := config.NewConfigManager("./config/config.yaml")
+	cfgManager, err := src.load()
 	if err != nil {
 		return nil, fmt.Errorf("could not load configuration: %w", err)
 	}
 	cfg := cfgManager.GetAppConfig()
+	if cfg == nil {
+		return nil, fmt.Errorf("could not load configuration: config manager returned no config")
+	}
+
+	// The sections of the enabled services are validated as their options are applied
+	if err := config.Validate(cfg); err != nil {
+		return nil, err
+	}
 
 	/// Initialize Logger
-	log, err := logger.InitServiceLogger(cfg)
+	log, logLevels, err := logger.InitServiceLoggerWithLevel(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize default logger: %w", err)
 	}
-
 	// Step 3: Create Base Context with Config and Logger references
-	neoCtx, err := newContext(context.Background(), log, cfg)
+	neoCtx, err := newContext(context.Background(), log, logLevels, cfgManager)
 	if err != nil {
 		return nil, err
 	}
+	// The rules of the logger, for the middlewares logging bodies and headers
+	if neoCtx.redactor, err = logger.RedactorFromConfig(cfg.Logger.Redaction); err != nil {
+		return nil, errors.Join(err, neoCtx.release(context.Background()))
+	}
 
 	// Apply Options
 	for _, option := range options {
 		if err := option(neoCtx); err != nil {
-			return nil, fmt.Errorf("failed to apply option: %w", err)
+			// Release whatever the previous options already opened
+			stopErr := neoCtx.release(context.Background())
+			return nil, errors.Join(fmt.Errorf("failed to apply option: %w", err), stopErr)
+		}
+	}
+	// Routes registered by the options, e.g. WithConfigEndpoint
+	if err := neoCtx.RouteErrors(); err != nil {
+		stopErr := neoCtx.release(context.Background())
+		return nil, errors.Join(fmt.Errorf("invalid routes: %w", err), stopErr)
+	}
+
+	// Apply configuration changes while the app runs when the config manager supports reloading
+	if reloadable, ok := cfgManager.(config.Reloadable); ok && !neoCtx.configWatchDisabled {
+		watcher := &configWatcherComponent{ctx: neoCtx, manager: reloadable, sections: neoCtx.sections}
+		if err := neoCtx.RegisterComponent(watcher); err != nil {
+			stopErr := neoCtx.release(context.Background())
+			return nil, errors.Join(err, stopErr)
 		}
 	}
 
 	return &App{
-		Context: neoCtx,
-		Logger:  log,
-		Config:  cfgManager.GetAppConfig(),
+		Context:       neoCtx,
+		Logger:        log,
+		Config:        cfg,
+		ConfigManager: cfgManager,
 	}, nil
 }
 
-// Run starts the application, including the HTTP server.
+// Run starts the application and blocks until SIGINT or SIGTERM is received,
+// then drains in-flight work and shuts the application down.
+//
+//	os.Exit(neodata.ExitCode(app.Run()))
 func (a *App) Run() error {
+	return a.RunContext(context.Background())
+}
+
+// RunContext starts the registered components and the HTTP server, then blocks until ctx is
+// cancelled, a termination signal is received or the HTTP server fails. On the way out the
+// HTTP server stops accepting requests, in-flight requests and message handlers get the
+// grace period to finish, and Shutdown releases the components. It returns without starting
+// anything when a route could not be registered, see NeoCtx.RouteErrors.
+func (a *App) RunContext(ctx context.Context) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	a.Logger.Info("Starting application")
-	httpServer, err := a.Context.GetHTTPServer()
-	if err != nil {
-		a.Logger.Error("HTTP server not configured", zap.Error(err))
-		return fmt.Errorf("HTTP server not configured")
+	if err := a.Context.RouteErrors(); err != nil {
+		return errors.Join(fmt.Errorf("invalid routes: %w", err), a.Shutdown(context.Background()))
+	}
+	if err := a.Context.lifecycle.start(ctx); err != nil {
+		return errors.Join(err, a.Shutdown(context.Background()))
+	}
+
+	serverErr := make(chan error, 1)
+	if httpServer := a.Context.httpServer; httpServer != nil {
+		a.Logger.Info("Starting HTTP server")
+		go func() {
+			_, err := http.StartServer(httpServer, a.Config)
+			serverErr <- err
+		}()
+	} else {
+		a.Logger.Info("HTTP server not configured, running without it")
+	}
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+		a.Logger.Info("Termination requested, shutting down", zap.Error(context.Cause(ctx)))
+	case err := <-serverErr:
+		if err != nil {
+			a.Logger.Error("HTTP server failed", zap.Error(err))
+			runErr = err
+		}
+	}
+	stop() // A second signal terminates the process immediately
+
+	return errors.Join(runErr, a.drain(), a.Shutdown(context.Background()))
+}
+
+// drain stops the HTTP server and the message subscriber and waits up to the grace period
+// for in-flight requests and message handlers to complete.
+func (a *App) drain() error {
+	a.Context.shuttingDown.Store(true)
+	gracePeriod := a.Context.lifecycle.gracePeriod
+	a.Logger.Info("Draining in-flight work", zap.Duration("grace_period", gracePeriod))
+
+	ctx, cancel := context.WithTimeout(context.Background(), gracePeriod)
+	defer cancel()
+
+	var errs []error
+	if err := a.shutdownHTTPServer(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to drain HTTP requests: %w", err))
 	}
-	a.Logger.Info("Starting HTTP server")
-	if _, err := http.StartServer(httpServer, a.Config); err != nil {
-		a.Logger.Error("Failed to start HTTP server", zap.Error(err))
-		return err
+	if subscriber := a.Context.subscriber; subscriber != nil {
+		if err := subscriber.Drain(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to drain message handlers: %w", err))
+		}
+	}
+
+	if ctx.Err() != nil {
+		a.Logger.Error("Grace period exceeded", zap.Duration("grace_period", gracePeriod))
+		errs = append(errs, ErrGracePeriodExceeded)
+	}
+	return errors.Join(errs...)
+}
+
+// shutdownHTTPServer stops the HTTP server once, waiting for in-flight requests until ctx ends.
+func (a *App) shutdownHTTPServer(ctx context.Context) error {
+	server := a.Context.httpServer
+	if server == nil {
+		return nil
+	}
+
+	var err error
+	a.httpShutdown.Do(func() {
+		err = server.ShutdownWithContext(ctx)
+		if errors.Is(err, fiber.ErrNotRunning) {
+			err = nil
+		}
+	})
+	return err
+}
+
+// ErrGracePeriodExceeded is returned by Run when in-flight work did not finish within the grace period.
+var ErrGracePeriodExceeded = errors.New("grace period exceeded")
+
+// Exit codes returned by ExitCode.
+const (
+	ExitOK              = 0 // Clean shutdown
+	ExitFailure         = 1 // Startup, runtime or shutdown failure
+	ExitGracePeriodOver = 2 // In-flight work was cut off when the grace period ran out
+)
+
+// ExitCode maps the error returned by Run or RunContext to a process exit code.
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, ErrGracePeriodExceeded):
+		return ExitGracePeriodOver
+	default:
+		return ExitFailure
+	}
+}
+
+// WithGracePeriod sets how long in-flight requests and message handlers may take to finish on shutdown,
+// overriding app.grace_period.
+func WithGracePeriod(gracePeriod time.Duration) Option {
+	return func(ctx *NeoCtx) error {
+		if gracePeriod <= 0 {
+			return fmt.Errorf("grace period must be positive, got %s", gracePeriod)
+		}
+		ctx.lifecycle.gracePeriod = gracePeriod
+		return nil
+	}
+}
+
+// WithGlobalLogger makes the app logger the global zap logger, used by logger.FromContext for
+// contexts without a request or message logger, and the default log/slog logger, used by the
+// libraries logging through log/slog. Without it the process-wide loggers are left untouched.
+func WithGlobalLogger() Option {
+	return func(ctx *NeoCtx) error {
+		zap.ReplaceGlobals(ctx.Logger)
+		slog.SetDefault(slog.New(logger.NewSlogHandler(logger.Wrap(ctx.Logger))))
+		return nil
+	}
+}
+
+// WithoutConfigWatch keeps the configuration loaded at startup: changes of the config file are not
+// reloaded while the app runs.
+func WithoutConfigWatch() Option {
+	return func(ctx *NeoCtx) error {
+		ctx.configWatchDisabled = true
+		return nil
 	}
-	return nil
 }
 
 // WithPostgres configures a PostgreSQL pool.
 func WithPostgres() Option {
-	return func(ctx *NeoCtx) error {
-		pool, err := postgres.NewPool(ctx.Context, ctx.Config)
+	return requires(func(ctx *NeoCtx) error {
+		log := ctx.ModuleLogger(logger.ModuleDB)
+		pool, err := postgres.NewPoolWithLogger(ctx.Context, ctx.Config, logger.Wrap(log))
 		if err != nil {
-			ctx.Logger.Error("Failed to initialize PostgreSQL", zap.Error(err))
+			log.Error("Failed to initialize PostgreSQL", zap.Error(err))
 			return fmt.Errorf("failed to initialize PostgreSQL: %w", err)
 		}
 		ctx.db = pool
-		ctx.Logger.Info("PostgreSQL connection pool initialized")
-		return nil
-	}
+		log.Info("PostgreSQL connection pool initialized")
+		return ctx.RegisterComponent(&postgresComponent{pool: pool})
+	}, config.SectionDatabase)
 }
 
 // WithNATS configures a NATS client.
 func WithNATS() Option {
-	return func(ctx *NeoCtx) error {
+	return requires(func(ctx *NeoCtx) error {
 		if ctx.messaging != nil {
 			ctx.Logger.Warn("Messaging client already configured, skipping NATS setup")
 			return nil
 		}
-		natsClient, err := messaging.NewNATSClient(ctx.Context, ctx.Config.Messaging.PubsubBroker)
+		log := ctx.ModuleLogger(logger.ModuleMessaging)
+		natsClient, err := messaging.NewNATSClientWithLogger(ctx.Context, ctx.Config.Messaging.PubsubBroker, logger.Wrap(log))
 		if err != nil {
-			ctx.Logger.Error("Failed to initialize NATS client", zap.Error(err))
+			log.Error("Failed to initialize NATS client", zap.Error(err))
 			return fmt.Errorf("failed to initialize NATS client: %w", err)
 		}
 		ctx.messaging = messaging.NewPublisher(natsClient, 0, 0)
-		ctx.Logger.Info("NATS messaging client initialized")
-		return nil
-	}
+		ctx.subscriber = messaging.NewSubscriber(natsClient, log)
+		log.Info("NATS messaging client initialized")
+		return ctx.RegisterComponent(&natsComponent{client: natsClient, subscriber: ctx.subscriber})
+	}, config.SectionMessaging)
 }
 
 // WithPolicyManager configures a Policy Manager.
 func WithPolicyManager() Option {
-	return func(ctx *NeoCtx) error {
-		policyManager, err := policy.NewPolicyManager(ctx.Config)
+	return requires(func(ctx *NeoCtx) error {
+		log := ctx.ModuleLogger(logger.ModulePolicy)
+		policyManager, err := policy.NewPolicyManagerWithLogger(ctx.Config, logger.Wrap(log))
 		if err != nil {
-			ctx.Logger.Error("Failed to initialize Policy Manager", zap.Error(err))
+			log.Error("Failed to initialize Policy Manager", zap.Error(err))
 			return fmt.Errorf("failed to initialize Policy Manager: %w", err)
 		}
 		ctx.policyManager = policyManager
-		ctx.Logger.Info("Policy Manager initialized")
-		return nil
+		log.Info("Policy Manager initialized")
+		return ctx.RegisterComponent(newPolicyComponent(policyManager, log, policyReloadInterval(ctx.Config)))
+	}, config.SectionDatabase)
+}
+
+// WithRedis configures a Redis cache.
+func WithRedis() Option {
+	return requires(func(ctx *NeoCtx) error {
+		ctx.cache = cache.NewRedisCacheFromConfig(ctx.Config)
+		ctx.Logger.Info("Redis cache initialized")
+		return ctx.RegisterComponent(&redisComponent{cache: ctx.cache})
+	}, config.SectionRedis)
+}
+
+// WithTracing configures an OpenTelemetry tracer provider for the service.
+func WithTracing() Option {
+	return func(ctx *NeoCtx) error {
+		tracer, err := tracing.NewTracer(ctx.Config.App.Name)
+		if err != nil {
+			ctx.Logger.Error("Failed to initialize tracer", zap.Error(err))
+			return fmt.Errorf("failed to initialize tracer: %w", err)
+		}
+		ctx.tracer = tracer
+		ctx.Logger.Info("Tracer initialized")
+		return ctx.RegisterComponent(&tracerComponent{tracer: tracer})
 	}
 }
 
 // WithHTTPServer configures an HTTP server.
 func WithHTTPServer() Option {
 	return func(ctx *NeoCtx) error {
-		ctx.httpServer = http.NewHTTPServer(ctx.Config, ctx.Logger)
+		// Requests per minute and client IP, adjusted when app.rate_limit is reloaded
+		ctx.rateLimiter = http.NewRateLimiter(ctx.Config.App.RateLimit, time.Minute)
+		ctx.httpServer = http.NewHTTPServer(ctx.Config, ctx.ModuleLogger(logger.ModuleHTTP), ctx.rateLimiter.Handler())
 		ctx.Logger.Info("HTTP server initialized")
 		return nil
 	}
 }
 
-// Shutdown gracefully shuts down the app's services
+// Shutdown gracefully shuts down the app's services. The HTTP server stops accepting
+// requests first, then the registered components are stopped in reverse dependency order and
+// the log files and syslog connections of the logger are closed.
+// Every failure is collected and returned as a single error.
 func (a *App) Shutdown(ctx context.Context) error {
-	if db, err := a.Context.GetDB(); err == nil {
-		db.Close()
+	var errs []error
+	if err := a.shutdownHTTPServer(ctx); err != nil {
+		a.Logger.Error("Failed to shut down HTTP server", zap.Error(err))
+		errs = append(errs, fmt.Errorf("failed to shut down HTTP server: %w", err))
 	}
 
-	if server, err := a.Context.GetHTTPServer(); err == nil {
-		return server.ShutdownWithContext(ctx)
+	if err := a.Context.release(ctx); err != nil {
+		errs = append(errs, err)
 	}
 
-	return nil
+	return errors.Join(errs...)
+}
+
+// release stops the components, then closes the log files and syslog connections once nothing
+// logs anymore.
+func (n *NeoCtx) release(ctx context.Context) error {
+	stopErr := n.lifecycle.stop(ctx)
+	if err := logger.Close(n.Logger); err != nil {
+		return errors.Join(stopErr, fmt.Errorf("failed to close logger: %w", err))
+	}
+	return stopErr
 }
 
 /* func (n *neodata.NeoCtx) StartMetricsServer() {
@@ -0,0 +1,156 @@
+package neodata
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/neodata-io/neodata-go/config"
+	"go.uber.org/zap"
+)
+
+func TestNewAppliesFuncOptions(t *testing.T) {
+	var applied *NeoCtx
+	app := newTestApp(t, func(ctx *NeoCtx) error {
+		applied = ctx
+		return nil
+	})
+	if applied != app.Context {
+		t.Fatal("custom option was not applied to the app context")
+	}
+
+	cfg := &config.AppConfig{}
+	cfg.App.Name, cfg.App.Port, cfg.App.Env = "test", 8080, "dev"
+	cfg.Logger.LogLevel = "error"
+	failing := errors.New("boom")
+	if _, err := NewWithConfig([]ConfigOption{WithAppConfig(cfg)}, func(*NeoCtx) error { return failing }); !errors.Is(err, failing) {
+		t.Errorf("NewWithConfig() error = %v, want %v", err, failing)
+	}
+}
+
+func TestNewValidatesRequiredSections(t *testing.T) {
+	cfg := &config.AppConfig{}
+	cfg.App.Name, cfg.App.Port, cfg.App.Env = "test", 8080, "dev"
+	cfg.Logger.LogLevel = "error"
+
+	// The postgres pool is never dialed: the empty database section is rejected first
+	_, err := NewWithConfig([]ConfigOption{WithAppConfig(cfg)}, WithPostgres())
+	var validationErr *config.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("NewWithConfig() error = %v, want a *config.ValidationError", err)
+	}
+	for _, field := range validationErr.Fields {
+		if !strings.HasPrefix(field.Key, config.SectionDatabase+".") {
+			t.Errorf("invalid key %s outside of the database section", field.Key)
+		}
+	}
+}
+
+func TestWithGlobalLogger(t *testing.T) {
+	globalLogger, defaultSlog := zap.L(), slog.Default()
+	t.Cleanup(func() {
+		zap.ReplaceGlobals(globalLogger)
+		slog.SetDefault(defaultSlog)
+	})
+
+	app := newTestApp(t)
+	if zap.L() == app.Logger || slog.Default() != defaultSlog {
+		t.Fatal("New replaced the global loggers without WithGlobalLogger")
+	}
+
+	app = newTestApp(t, WithGlobalLogger())
+	if zap.L() != app.Logger {
+		t.Error("global zap logger is not the app logger")
+	}
+	if slog.Default() == defaultSlog {
+		t.Error("default slog logger not replaced")
+	}
+}
+
+// signalingComponent calls onStart once started, e.g. to stop the app under test.
+type signalingComponent struct {
+	onStart func()
+	stopped bool
+}
+
+func (c *signalingComponent) Name() string { return "signaling" }
+
+func (c *signalingComponent) Start(context.Context) error {
+	go c.onStart()
+	return nil
+}
+
+func (c *signalingComponent) Stop(context.Context) error {
+	c.stopped = true
+	return nil
+}
+
+func TestRunContextStopsOnCancel(t *testing.T) {
+	app := newTestApp(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	component := &signalingComponent{onStart: cancel}
+	if err := app.Context.RegisterComponent(component); err != nil {
+		t.Fatalf("RegisterComponent() error = %v", err)
+	}
+
+	if err := app.RunContext(ctx); err != nil {
+		t.Fatalf("RunContext() error = %v", err)
+	}
+	if !component.stopped {
+		t.Error("component not stopped")
+	}
+	if report := app.Context.CheckReadiness(context.Background()); report.Status != StatusDown {
+		t.Errorf("readiness after shutdown = %s, want %s", report.Status, StatusDown)
+	}
+}
+
+func TestRunStopsOnSIGTERM(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("signals cannot be sent on windows")
+	}
+	app := newTestApp(t)
+	// Run listens to the signals before starting the components
+	component := &signalingComponent{onStart: func() {
+		process, _ := os.FindProcess(os.Getpid())
+		_ = process.Signal(syscall.SIGTERM)
+	}}
+	if err := app.Context.RegisterComponent(component); err != nil {
+		t.Fatalf("RegisterComponent() error = %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- app.Run() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run() did not return after SIGTERM")
+	}
+	if !component.stopped {
+		t.Error("component not stopped")
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{nil, ExitOK},
+		{errors.New("failed to start component db"), ExitFailure},
+		{errors.Join(errors.New("failed to drain"), ErrGracePeriodExceeded), ExitGracePeriodOver},
+	}
+	for _, tt := range tests {
+		if got := ExitCode(tt.err); got != tt.want {
+			t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
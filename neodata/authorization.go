@@ -0,0 +1,43 @@
+package neodata
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/neodata-io/neodata-go/config"
+	"github.com/neodata-io/neodata-go/infrastructure/transport/http"
+)
+
+// Authenticated requires a valid bearer token, checked with AuthMiddleware and auth.jwtSecret.
+// Registering the route fails when the auth section of the configuration is invalid.
+func Authenticated() RouteOption {
+	return func(cfg *routeConfig) {
+		if cfg.authenticated {
+			return
+		}
+		cfg.authenticated = true
+		cfg.middlewares = append(cfg.middlewares, func(ctx *NeoCtx) (fiber.Handler, error) {
+			if err := ctx.requireSections(config.SectionAuth); err != nil {
+				return nil, err
+			}
+			return http.AuthMiddleware(ctx.Config.Auth.JwtSecret), nil
+		})
+	}
+}
+
+// Permission requires the authenticated user to be allowed to perform action on resource by the
+// policy manager. The resource may reference path params, e.g. Permission("orders:{id}", "read").
+// Authentication is added to the route when it is not already required.
+func Permission(resource, action string) RouteOption {
+	return func(cfg *routeConfig) {
+		Authenticated()(cfg)
+		cfg.permissions = append(cfg.permissions, PermissionInfo{Resource: resource, Action: action})
+		cfg.middlewares = append(cfg.middlewares, func(ctx *NeoCtx) (fiber.Handler, error) {
+			policyManager, err := ctx.GetPolicyManager()
+			if err != nil {
+				return nil, fmt.Errorf("permission %s on %s requires the policy manager: %w", action, resource, err)
+			}
+			return http.RequirePermission(policyManager, ctx.Logger, resource, action), nil
+		})
+	}
+}
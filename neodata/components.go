@@ -0,0 +1,222 @@
+package neodata
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/neodata-io/neodata-go/config"
+	"github.com/neodata-io/neodata-go/infrastructure/auth/policy"
+	"github.com/neodata-io/neodata-go/infrastructure/cache"
+	"github.com/neodata-io/neodata-go/infrastructure/messaging"
+	tracing "github.com/neodata-io/neodata-go/infrastructure/observability"
+	"go.uber.org/zap"
+)
+
+// Names of the built-in components, usable in Dependent.DependsOn.
+const (
+	ComponentPostgres      = "postgres"
+	ComponentNATS          = "nats"
+	ComponentRedis         = "redis"
+	ComponentPolicyManager = "policy_manager"
+	ComponentTracer        = "tracer"
+	ComponentConfigWatcher = "config_watcher"
+)
+
+// postgresComponent verifies the pool on start and closes it on stop.
+type postgresComponent struct {
+	pool *pgxpool.Pool
+}
+
+func (c *postgresComponent) Name() string { return ComponentPostgres }
+
+func (c *postgresComponent) Start(ctx context.Context) error {
+	return c.pool.Ping(ctx)
+}
+
+func (c *postgresComponent) Stop(ctx context.Context) error {
+	// Close blocks until every acquired connection is released
+	return waitFor(ctx, c.pool.Close)
+}
+
+// natsComponent drains the subscriber and then the NATS connection on stop.
+type natsComponent struct {
+	client     *messaging.NATSClient
+	subscriber *messaging.Subscriber
+}
+
+func (c *natsComponent) Name() string { return ComponentNATS }
+
+func (c *natsComponent) Start(context.Context) error { return nil }
+
+func (c *natsComponent) Stop(ctx context.Context) error {
+	if err := c.subscriber.Drain(ctx); err != nil {
+		return errors.Join(err, c.client.Drain(ctx))
+	}
+	return c.client.Drain(ctx)
+}
+
+// redisComponent verifies the connection on start and closes the client on stop.
+type redisComponent struct {
+	cache *cache.RedisCache
+}
+
+func (c *redisComponent) Name() string { return ComponentRedis }
+
+func (c *redisComponent) Start(ctx context.Context) error {
+	return c.cache.Ping(ctx)
+}
+
+func (c *redisComponent) Stop(context.Context) error {
+	return c.cache.Close()
+}
+
+// policyReloader is the part of policy.PolicyManager the policy component uses.
+type policyReloader interface {
+	ReloadPolicies() error
+	Close() error
+}
+
+var _ policyReloader = (*policy.PolicyManager)(nil)
+
+// policyComponent reloads the policies periodically and releases the Casbin enforcer on stop.
+type policyComponent struct {
+	manager policyReloader
+	logger  *zap.Logger
+
+	mu       sync.Mutex
+	interval time.Duration // Zero disables periodic reloads
+	wake     chan struct{} // Signals an interval change to the reload loop
+	done     chan struct{}
+	loop     sync.WaitGroup // Running reload loop, waited for before the manager is closed
+}
+
+func newPolicyComponent(manager policyReloader, logger *zap.Logger, interval time.Duration) *policyComponent {
+	return &policyComponent{
+		manager:  manager,
+		logger:   logger,
+		interval: interval,
+		wake:     make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+}
+
+func (c *policyComponent) Name() string { return ComponentPolicyManager }
+
+func (c *policyComponent) Start(context.Context) error {
+	c.loop.Add(1)
+	go c.reloadLoop()
+	return nil
+}
+
+func (c *policyComponent) Stop(ctx context.Context) error {
+	close(c.done)
+	// A reload in progress must not run against a closed manager
+	if err := waitFor(ctx, c.loop.Wait); err != nil {
+		return err
+	}
+	return c.manager.Close()
+}
+
+// setReloadInterval changes the period of the policy reloads, zero disables them.
+func (c *policyComponent) setReloadInterval(interval time.Duration) {
+	c.mu.Lock()
+	c.interval = interval
+	c.mu.Unlock()
+
+	select {
+	case c.wake <- struct{}{}:
+	default: // A change is already pending
+	}
+}
+
+// reloadLoop reloads the policies from the database every interval until the component stops.
+func (c *policyComponent) reloadLoop() {
+	defer c.loop.Done()
+
+	var ticker *time.Ticker
+	defer func() {
+		if ticker != nil {
+			ticker.Stop()
+		}
+	}()
+
+	for {
+		if ticker != nil {
+			ticker.Stop()
+			ticker = nil
+		}
+		c.mu.Lock()
+		interval := c.interval
+		c.mu.Unlock()
+
+		var tick <-chan time.Time
+		if interval > 0 {
+			ticker = time.NewTicker(interval)
+			tick = ticker.C
+		}
+
+	wait:
+		for {
+			select {
+			case <-c.done:
+				return
+			case <-c.wake:
+				break wait
+			case <-tick:
+				if err := c.manager.ReloadPolicies(); err != nil {
+					c.logger.Error("Failed to reload policies", zap.Error(err))
+				}
+			}
+		}
+	}
+}
+
+// configWatcherComponent reloads the configuration while the app runs, see NeoCtx.watchConfig.
+// It is registered last so that it stops first, before the components its reloads reconfigure.
+type configWatcherComponent struct {
+	ctx      *NeoCtx
+	manager  config.Reloadable
+	sections []string
+}
+
+func (c *configWatcherComponent) Name() string { return ComponentConfigWatcher }
+
+func (c *configWatcherComponent) Start(context.Context) error {
+	return c.ctx.watchConfig(c.manager, c.sections)
+}
+
+func (c *configWatcherComponent) Stop(context.Context) error {
+	return c.manager.StopWatching()
+}
+
+// tracerComponent flushes and shuts down the tracer provider on stop.
+type tracerComponent struct {
+	tracer *tracing.Tracer
+}
+
+func (c *tracerComponent) Name() string { return ComponentTracer }
+
+func (c *tracerComponent) Start(context.Context) error { return nil }
+
+func (c *tracerComponent) Stop(ctx context.Context) error {
+	return c.tracer.Shutdown(ctx)
+}
+
+// waitFor runs a blocking close function, returning early if ctx ends first.
+func waitFor(ctx context.Context, fn func()) error {
+	done := make(chan struct{})
+	go func() {
+		fn()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,66 @@
+package neodata
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// blockingPolicies is a policy manager whose reloads block until release is closed.
+type blockingPolicies struct {
+	reloading chan struct{}
+	started   sync.Once
+	release   chan struct{}
+	inReload  atomic.Bool
+	closedMid atomic.Bool // Close was called during a reload
+}
+
+func (p *blockingPolicies) ReloadPolicies() error {
+	p.inReload.Store(true)
+	p.started.Do(func() { close(p.reloading) })
+	<-p.release
+	p.inReload.Store(false)
+	return nil
+}
+
+func (p *blockingPolicies) Close() error {
+	p.closedMid.Store(p.inReload.Load())
+	return nil
+}
+
+func TestPolicyComponentStopWaitsForReload(t *testing.T) {
+	policies := &blockingPolicies{reloading: make(chan struct{}), release: make(chan struct{})}
+	component := newPolicyComponent(policies, zap.NewNop(), time.Millisecond)
+	if err := component.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	<-policies.reloading
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- component.Stop(context.Background()) }()
+
+	select {
+	case <-stopped:
+		t.Fatal("Stop() returned during a reload")
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(policies.release)
+	if err := <-stopped; err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	if policies.closedMid.Load() {
+		t.Error("manager closed during a reload")
+	}
+}
+
+func TestPolicyComponentStopWithoutStart(t *testing.T) {
+	policies := &blockingPolicies{}
+	component := newPolicyComponent(policies, zap.NewNop(), time.Minute)
+	if err := component.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+}
@@ -3,12 +3,21 @@ package neodata
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/gofiber/fiber/v3"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/neodata-io/neodata-go/config"
 	"github.com/neodata-io/neodata-go/infrastructure/auth/policy"
+	"github.com/neodata-io/neodata-go/infrastructure/cache"
 	"github.com/neodata-io/neodata-go/infrastructure/messaging"
+	tracing "github.com/neodata-io/neodata-go/infrastructure/observability"
+	"github.com/neodata-io/neodata-go/infrastructure/transport/http"
+	"github.com/neodata-io/neodata-go/logger"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
@@ -16,27 +25,94 @@ import (
 // encapsulates dependency management and provides a structured way to access shared services.
 type NeoCtx struct {
 	Context context.Context
-	Config  *config.AppConfig
-	Logger  *zap.Logger // Injected from the main application to enable structured logging
+	Config  *config.AppConfig // Configuration at startup, see App.ConfigManager for the reloaded one
+	Logger  *zap.Logger       // Injected from the main application to enable structured logging
+
+	configManager config.ConfigManager // Serves the current configuration, see WithConfigEndpoint
+	logLevels     *logger.Levels       // Levels of Logger and its modules, follow logger.log_level and logger.modules on reload
+	redactor      *logger.Redactor     // Redaction rules of Logger, nil if disabled
+	rateLimiter   *http.RateLimiter    // Follows app.rate_limit on reload, nil without HTTP server
+
+	configWatchDisabled bool     // Set by WithoutConfigWatch
+	sections            []string // Config sections the options require, validated again on reload
 
 	db            *pgxpool.Pool
 	httpServer    *fiber.App
 	policyManager *policy.PolicyManager
 	messaging     messaging.Messaging
+	subscriber    *messaging.Subscriber
+	cache         *cache.RedisCache
+	tracer        *tracing.Tracer
 	Services      *ServiceRegistry // Add a dynamic service registry
+
+	lifecycle *lifecycle     // Components started and stopped with the App
+	routes    *routeRegistry // Metadata of the routes registered through Router
+
+	healthMu     sync.Mutex
+	healthChecks []namedCheck // Custom readiness checks
+	shuttingDown atomic.Bool  // Set once the app starts draining, fails readiness
+}
+
+// requireSections validates the given config sections and keeps them validated on reload.
+// The app and logger sections were validated by New.
+func (n *NeoCtx) requireSections(sections ...string) error {
+	if err := config.ValidateSections(n.Config, sections...); err != nil {
+		return err
+	}
+	for _, section := range sections {
+		if !slices.Contains(n.sections, section) {
+			n.sections = append(n.sections, section)
+		}
+	}
+	return nil
 }
 
 // NewContext initializes a new Neo Context
 // Components can be nil if not used by the microservice.
-func newContext(ctx context.Context, l *zap.Logger, cfg *config.AppConfig) (*NeoCtx, error) {
+func newContext(ctx context.Context, l *zap.Logger, levels *logger.Levels, manager config.ConfigManager) (*NeoCtx, error) {
+	cfg := manager.GetAppConfig()
 	return &NeoCtx{
-		Context:  ctx,
-		Logger:   l,
-		Config:   cfg,
-		Services: &ServiceRegistry{},
+		Context:       ctx,
+		Logger:        l,
+		Config:        cfg,
+		configManager: manager,
+		logLevels:     levels,
+		Services:      &ServiceRegistry{},
+		lifecycle:     newLifecycle(l, cfg.App.GracePeriod*time.Second),
+		routes:        &routeRegistry{},
 	}, nil
 }
 
+// ModuleLogger returns the logger of a module, e.g. logger.ModuleDB for repositories, whose level
+// is set by logger.modules or the log level endpoint, see WithLogLevelEndpoint.
+func (n *NeoCtx) ModuleLogger(module string) *zap.Logger {
+	return n.logLevels.Named(n.Logger, module)
+}
+
+// Redactor returns the redaction rules of the logger, nil when logger.redaction is disabled.
+// Middlewares logging bodies and headers use it to hide the same values as the logger:
+//
+//	router.Use(http.LoggerMiddlewareWithRedactor(ctx.Redactor()))
+func (n *NeoCtx) Redactor() *logger.Redactor {
+	return n.redactor
+}
+
+// exemptFromRateLimit serves the paths without the app.rate_limit limit.
+func (n *NeoCtx) exemptFromRateLimit(paths ...string) {
+	if n.rateLimiter != nil {
+		n.rateLimiter.Exempt(paths...)
+	}
+}
+
+// RegisterComponent adds a component whose Start and Stop are driven by the App lifecycle.
+func (n *NeoCtx) RegisterComponent(c Component) error {
+	if err := n.lifecycle.register(c); err != nil {
+		n.Logger.Error("Failed to register component", zap.String("component", c.Name()), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
 // GetDB retrieves the database pool, logging an error if it is not configured.
 func (n *NeoCtx) GetDB() (*pgxpool.Pool, error) {
 	if n.db == nil {
@@ -78,11 +154,30 @@ func (n *NeoCtx) GetPublisher() (messaging.Messaging, error) {
 }
 
 // GetSubscriber retrieves the messaging subscriber, logging an error if it is not configured.
-func (n *NeoCtx) GetSubscriber() (messaging.Messaging, error) {
-	if n.messaging == nil {
+func (n *NeoCtx) GetSubscriber() (*messaging.Subscriber, error) {
+	if n.subscriber == nil {
 		n.Logger.Error("Messaging client not configured")
 		return nil, fmt.Errorf("messaging client not configured")
 	}
 	n.Logger.Info("Messaging subscriber retrieved successfully")
-	return n.messaging, nil
+	return n.subscriber, nil
+}
+
+// GetCache retrieves the Redis cache, logging an error if it is not configured.
+func (n *NeoCtx) GetCache() (*cache.RedisCache, error) {
+	if n.cache == nil {
+		n.Logger.Error("Cache not configured")
+		return nil, fmt.Errorf("cache not configured")
+	}
+	n.Logger.Info("Cache retrieved successfully")
+	return n.cache, nil
+}
+
+// GetTracer retrieves a named tracer, logging an error if tracing is not configured.
+func (n *NeoCtx) GetTracer(name string) (trace.Tracer, error) {
+	if n.tracer == nil {
+		n.Logger.Error("Tracer not configured")
+		return nil, fmt.Errorf("tracer not configured")
+	}
+	return n.tracer.Tracer(name), nil
 }
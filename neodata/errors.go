@@ -0,0 +1,30 @@
+package neodata
+
+import (
+	"github.com/neodata-io/neodata-go/errors"
+	"github.com/neodata-io/neodata-go/infrastructure/auth/policy"
+	"github.com/neodata-io/neodata-go/infrastructure/db/postgres"
+	"github.com/neodata-io/neodata-go/infrastructure/messaging"
+)
+
+// translators convert the errors of the infrastructure packages into AppErrors.
+var translators = []func(error) error{
+	postgres.TranslateError,
+	messaging.TranslateError,
+	policy.TranslateError,
+}
+
+// translateError converts an infrastructure error returned by a handler, such as a pgx unique
+// violation, into an AppError wrapping it, so it is rendered with its status instead of a 500.
+func translateError(err error) error {
+	if _, ok := errors.As(err); ok {
+		return err
+	}
+	for _, translate := range translators {
+		translated := translate(err)
+		if _, ok := errors.As(translated); ok {
+			return translated
+		}
+	}
+	return err
+}
@@ -0,0 +1,166 @@
+package neodata
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/neodata-io/neodata-go/errors"
+	"github.com/neodata-io/neodata-go/util"
+)
+
+// TypedHandler handles a request bound into Req and returns the Resp sent back as JSON.
+type TypedHandler[Req, Resp any] func(ctx *RequestCtx, req Req) (Resp, error)
+
+// Handle registers a typed handler on the router.
+//
+// Before the handler runs, Req is bound from the request using struct tags:
+// the JSON body (`json`), then query string (`query`), headers (`header`) and path params (`uri`).
+// The query string, headers and path params only set the fields tagged for them, and path params
+// are bound last, so no other part of the request can override them, e.g. the id checked by
+// Permission("orders:{id}"). The result is validated with util.GetValidator; binding
+// failures are returned as a 400 bad_request problem and validation failures as a 400
+// validation_failed problem listing the invalid fields.
+//
+//	type GetOrder struct {
+//		ID     string `uri:"id" validate:"required,uuid4"`
+//		Expand bool   `query:"expand"`
+//	}
+//	neodata.Handle(router, "GET", "/orders/:id", func(ctx *neodata.RequestCtx, req GetOrder) (*Order, error) { ... })
+func Handle[Req, Resp any](r *Router, method, path string, handler TypedHandler[Req, Resp], opts ...RouteOption) {
+	r.add([]string{method}, path, func(c fiber.Ctx) error {
+		req, err := bindRequest[Req](c)
+		if err != nil {
+			return err // Already a problem rendered by the server's error handler
+		}
+
+		reqCtx, cancel := newRequestCtx(r.ctx, c)
+		defer cancel()
+
+		result, err := handler(reqCtx, req)
+		if err != nil {
+			return translateError(err) // Rendered by the server's error handler
+		}
+		return c.JSON(result)
+	}, reflect.TypeFor[Req](), reflect.TypeFor[Resp](), opts)
+}
+
+// bindRequest fills a new Req from the request and validates it.
+// Only struct types are bound from params, query and headers; any type can be bound from the body.
+// The body is bound first so that query, headers and params take precedence over it.
+func bindRequest[Req any](c fiber.Ctx) (Req, error) {
+	var req Req
+	target := any(&req)
+
+	// Pointer request types are allocated and bound in place
+	t := reflect.TypeOf(req)
+	if t != nil && t.Kind() == reflect.Ptr {
+		req = reflect.New(t.Elem()).Interface().(Req)
+		target = req
+	}
+
+	if len(c.Body()) > 0 {
+		if err := c.Bind().JSON(target); err != nil {
+			return req, errors.BadRequest("invalid request").WithCause(fmt.Errorf("failed to bind body: %w", err))
+		}
+	}
+
+	if !isStruct(t) {
+		return req, nil
+	}
+
+	if err := bindTagged(target, "query", c.Bind().Query, func(key string) bool {
+		return c.Request().URI().QueryArgs().Has(key)
+	}); err != nil {
+		return req, errors.BadRequest("invalid request").WithCause(fmt.Errorf("failed to bind query: %w", err))
+	}
+	if err := bindTagged(target, "header", c.Bind().Header, func(key string) bool {
+		return len(c.Request().Header.Peek(key)) > 0
+	}); err != nil {
+		return req, errors.BadRequest("invalid request").WithCause(fmt.Errorf("failed to bind headers: %w", err))
+	}
+	if err := bindTagged(target, "uri", c.Bind().URI, func(key string) bool {
+		return c.Params(key) != ""
+	}); err != nil {
+		return req, errors.BadRequest("invalid request").WithCause(fmt.Errorf("failed to bind path params: %w", err))
+	}
+
+	if err := util.GetValidator().Struct(req); err != nil {
+		return req, validationError(err)
+	}
+	return req, nil
+}
+
+// bindTagged binds one part of the request into the fields of target tagged with tag whose key is
+// present in it. The Fiber binders also match untagged fields by name, e.g. ?id=1 sets a field ID
+// tagged uri:"id", so they bind into a copy and only the tagged fields are copied into target.
+func bindTagged(target any, tag string, bind func(any) error, present func(key string) bool) error {
+	dst := reflect.ValueOf(target).Elem()
+	if !hasTag(dst.Type(), tag) {
+		return nil
+	}
+	src := reflect.New(dst.Type())
+	if err := bind(src.Interface()); err != nil {
+		return err
+	}
+	copyTagged(dst, src.Elem(), tag, present)
+	return nil
+}
+
+// hasTag reports whether a field of the struct t, or of its embedded structs, is tagged with tag.
+func hasTag(t reflect.Type, tag string) bool {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if _, ok := field.Tag.Lookup(tag); ok {
+			return true
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && hasTag(field.Type, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// copyTagged copies the fields of src tagged with tag whose key is present into dst.
+func copyTagged(dst, src reflect.Value, tag string, present func(key string) bool) {
+	for i := 0; i < dst.NumField(); i++ {
+		field := dst.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		key, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		switch {
+		case key != "" && key != "-":
+			if present(key) {
+				dst.Field(i).Set(src.Field(i))
+			}
+		case field.Anonymous && field.Type.Kind() == reflect.Struct:
+			copyTagged(dst.Field(i), src.Field(i), tag, present)
+		}
+	}
+}
+
+// validationError converts a validator error into a validation_failed problem detailing each invalid field.
+func validationError(err error) error {
+	problems := util.FormatValidationErrors(err)
+	if len(problems) == 0 {
+		return errors.BadRequest("invalid request").WithCause(err)
+	}
+	fields := make(map[string]string, len(problems))
+	for _, problem := range problems {
+		fields[problem.Field] = problem.Message
+	}
+	return errors.Validation("invalid request", fields).WithCause(err)
+}
+
+// isStruct reports whether t is a struct or a pointer to one.
+func isStruct(t reflect.Type) bool {
+	if t == nil {
+		return false
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
@@ -0,0 +1,114 @@
+package neodata
+
+import (
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/neodata-io/neodata-go/config"
+)
+
+func newTestApp(t *testing.T, options ...Option) *App {
+	t.Helper()
+	cfg := &config.AppConfig{}
+	cfg.App.Name, cfg.App.Port, cfg.App.Env = "test", 8080, "dev"
+	cfg.Logger.LogLevel = "error"
+	cfg.Auth.JwtSecret, cfg.Auth.TokenExpiry = "secret", 3600
+	app, err := NewWithConfig([]ConfigOption{WithAppConfig(cfg)}, options...)
+	if err != nil {
+		t.Fatalf("NewWithConfig() error = %v", err)
+	}
+	return app
+}
+
+type updateOrder struct {
+	ID     string `uri:"id" json:"id"`
+	Status string `json:"status" validate:"required"`
+	Notify bool   `query:"notify" json:"notify"`
+	Tenant string `header:"X-Tenant" json:"tenant"`
+}
+
+func TestHandleBindsPathParamsOverOtherSources(t *testing.T) {
+	app := newTestApp(t, WithHTTPServer())
+	Handle(NewRouter(app.Context), "PUT", "/orders/:id", func(ctx *RequestCtx, req updateOrder) (updateOrder, error) {
+		return req, nil
+	})
+	srv, _ := app.Context.GetHTTPServer()
+
+	for _, tt := range []struct {
+		name    string
+		target  string
+		body    string
+		headers map[string]string
+		want    updateOrder
+	}{
+		{
+			name:   "body",
+			target: "/orders/42",
+			body:   `{"id":"43","status":"paid"}`,
+			want:   updateOrder{ID: "42", Status: "paid"},
+		},
+		{
+			name:   "query",
+			target: "/orders/42?id=99&notify=true",
+			body:   `{"status":"paid"}`,
+			want:   updateOrder{ID: "42", Status: "paid", Notify: true},
+		},
+		{
+			name:    "header",
+			target:  "/orders/42",
+			body:    `{"status":"paid"}`,
+			headers: map[string]string{"Id": "99", "X-Tenant": "acme"},
+			want:    updateOrder{ID: "42", Status: "paid", Tenant: "acme"},
+		},
+		{
+			name:   "untagged field",
+			target: "/orders/42?status=cancelled",
+			body:   `{"status":"paid"}`,
+			want:   updateOrder{ID: "42", Status: "paid"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("PUT", tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			resp, err := srv.Test(req)
+			if err != nil {
+				t.Fatalf("Test() error = %v", err)
+			}
+			var got updateOrder
+			if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("bound %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleReportsValidationFailures(t *testing.T) {
+	app := newTestApp(t, WithHTTPServer())
+	Handle(NewRouter(app.Context), "PUT", "/orders/:id", func(ctx *RequestCtx, req updateOrder) (updateOrder, error) {
+		return req, nil
+	})
+	srv, _ := app.Context.GetHTTPServer()
+
+	req := httptest.NewRequest("PUT", "/orders/42", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := srv.Test(req)
+	if err != nil {
+		t.Fatalf("Test() error = %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != 400 {
+		t.Fatalf("status = %d, want 400", resp.StatusCode)
+	}
+	if !strings.Contains(string(body), "validation_failed") || !strings.Contains(string(body), "Status") {
+		t.Errorf("body = %s, want a validation_failed problem detailing Status", body)
+	}
+}
@@ -0,0 +1,172 @@
+package neodata
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"go.uber.org/zap"
+)
+
+// healthCheckTimeout bounds a single readiness check.
+const healthCheckTimeout = 2 * time.Second
+
+// HealthCheck reports whether a dependency is ready to serve traffic.
+type HealthCheck func(ctx context.Context) error
+
+// HealthChecker is implemented by components that take part in the readiness check.
+type HealthChecker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckResult is the outcome of a single readiness check.
+type CheckResult struct {
+	Status   string `json:"status"`
+	Duration string `json:"duration"`
+	Error    string `json:"error,omitempty"` // Logged, never served by /readyz
+}
+
+// HealthReport is the JSON body served by the health endpoints.
+type HealthReport struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks,omitempty"`
+}
+
+// withoutErrors returns a copy of the report without the error of the checks, which may reveal
+// hosts, users or queries to unauthenticated clients.
+func (r HealthReport) withoutErrors() HealthReport {
+	checks := make(map[string]CheckResult, len(r.Checks))
+	for name, result := range r.Checks {
+		result.Error = ""
+		checks[name] = result
+	}
+	return HealthReport{Status: r.Status, Checks: checks}
+}
+
+// Health statuses reported by the endpoints.
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// namedCheck keeps custom checks in registration order.
+type namedCheck struct {
+	name  string
+	check HealthCheck
+}
+
+// AddHealthCheck registers a custom readiness check under the given name.
+func (n *NeoCtx) AddHealthCheck(name string, check HealthCheck) error {
+	n.healthMu.Lock()
+	defer n.healthMu.Unlock()
+
+	for _, existing := range n.healthChecks {
+		if existing.name == name {
+			return fmt.Errorf("health check %q already registered", name)
+		}
+	}
+	n.healthChecks = append(n.healthChecks, namedCheck{name: name, check: check})
+	return nil
+}
+
+// readinessChecks returns the checks of every component implementing HealthChecker followed by the custom checks.
+func (n *NeoCtx) readinessChecks() []namedCheck {
+	var checks []namedCheck
+	for _, c := range n.lifecycle.list() {
+		if checker, ok := c.(HealthChecker); ok {
+			checks = append(checks, namedCheck{name: c.Name(), check: checker.Check})
+		}
+	}
+
+	n.healthMu.Lock()
+	defer n.healthMu.Unlock()
+	return append(checks, n.healthChecks...)
+}
+
+// CheckReadiness runs every readiness check concurrently and aggregates the results.
+func (n *NeoCtx) CheckReadiness(ctx context.Context) HealthReport {
+	checks := n.readinessChecks()
+	report := HealthReport{Status: StatusUp, Checks: make(map[string]CheckResult, len(checks))}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, nc := range checks {
+		wg.Add(1)
+		go func(nc namedCheck) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+			defer cancel()
+
+			start := time.Now()
+			err := nc.check(checkCtx)
+			result := CheckResult{Status: StatusUp, Duration: time.Since(start).String()}
+			if err != nil {
+				result.Status = StatusDown
+				result.Error = err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[nc.name] = result
+			if err != nil {
+				report.Status = StatusDown
+			}
+		}(nc)
+	}
+	wg.Wait()
+
+	if n.shuttingDown.Load() {
+		report.Status = StatusDown
+	}
+	return report
+}
+
+// WithHealthChecks mounts /livez and /readyz on the HTTP server, exempt from the rate limit.
+// Readiness covers every registered component that implements HealthChecker
+// and the checks added through NeoCtx.AddHealthCheck. /readyz reports whether each check passed,
+// the errors of the failed checks are only logged.
+func WithHealthChecks() Option {
+	return func(ctx *NeoCtx) error {
+		httpServer, err := ctx.GetHTTPServer()
+		if err != nil {
+			return fmt.Errorf("health checks require the HTTP server: %w", err)
+		}
+
+		ctx.exemptFromRateLimit("/livez", "/readyz")
+		httpServer.Get("/livez", func(c fiber.Ctx) error {
+			return c.JSON(HealthReport{Status: StatusUp})
+		})
+
+		httpServer.Get("/readyz", func(c fiber.Ctx) error {
+			report := ctx.CheckReadiness(c.UserContext())
+			if report.Status != StatusUp {
+				ctx.Logger.Warn("Readiness check failed", zap.Any("checks", report.Checks))
+				return c.Status(fiber.StatusServiceUnavailable).JSON(report.withoutErrors())
+			}
+			return c.JSON(report.withoutErrors())
+		})
+
+		ctx.Logger.Info("Health check endpoints initialized")
+		return nil
+	}
+}
+
+// Check pings PostgreSQL.
+func (c *postgresComponent) Check(ctx context.Context) error {
+	return c.pool.Ping(ctx)
+}
+
+// Check verifies the NATS connection and JetStream.
+func (c *natsComponent) Check(ctx context.Context) error {
+	return c.client.Ping(ctx)
+}
+
+// Check pings Redis.
+func (c *redisComponent) Check(ctx context.Context) error {
+	return c.cache.Ping(ctx)
+}
@@ -0,0 +1,91 @@
+package neodata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+func TestRateLimitExemptsHealthChecks(t *testing.T) {
+	app := newTestApp(t, WithHTTPServer(), WithHealthChecks())
+	app.Context.rateLimiter.SetLimit(1)
+	NewRouter(app.Context).GET("/orders", func(ctx *RequestCtx) (interface{}, error) { return "ok", nil })
+	srv, _ := app.Context.GetHTTPServer()
+
+	for i := 0; i < 3; i++ {
+		resp, err := srv.Test(httptest.NewRequest("GET", "/livez", nil))
+		if err != nil {
+			t.Fatalf("Test() error = %v", err)
+		}
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("GET /livez #%d status = %d, want %d", i+1, resp.StatusCode, fiber.StatusOK)
+		}
+	}
+
+	statuses := []int{}
+	for i := 0; i < 2; i++ {
+		resp, err := srv.Test(httptest.NewRequest("GET", "/orders", nil))
+		if err != nil {
+			t.Fatalf("Test() error = %v", err)
+		}
+		statuses = append(statuses, resp.StatusCode)
+	}
+	if statuses[0] != fiber.StatusOK || statuses[1] != fiber.StatusTooManyRequests {
+		t.Errorf("GET /orders statuses = %v, want [200 429]", statuses)
+	}
+}
+
+func TestRateLimitKeepsAdminEndpoints(t *testing.T) {
+	app := newTestApp(t, WithHTTPServer(), WithLogLevelEndpoint(""))
+	app.Context.rateLimiter.SetLimit(1)
+	srv, _ := app.Context.GetHTTPServer()
+
+	statuses := []int{}
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest("GET", DefaultLogLevelEndpoint, nil)
+		req.Header.Set("Authorization", "Bearer guess")
+		resp, err := srv.Test(req)
+		if err != nil {
+			t.Fatalf("Test() error = %v", err)
+		}
+		statuses = append(statuses, resp.StatusCode)
+	}
+	if statuses[0] != fiber.StatusUnauthorized || statuses[1] != fiber.StatusTooManyRequests {
+		t.Errorf("GET %s statuses = %v, want [401 429]", DefaultLogLevelEndpoint, statuses)
+	}
+}
+
+func TestReadinessHidesCheckErrors(t *testing.T) {
+	app := newTestApp(t, WithHTTPServer(), WithHealthChecks())
+	if err := app.Context.AddHealthCheck("orders-db", func(context.Context) error {
+		return fmt.Errorf("dial tcp db.internal:5432: password authentication failed for user orders")
+	}); err != nil {
+		t.Fatalf("AddHealthCheck() error = %v", err)
+	}
+	srv, _ := app.Context.GetHTTPServer()
+
+	resp, err := srv.Test(httptest.NewRequest("GET", "/readyz", nil))
+	if err != nil {
+		t.Fatalf("Test() error = %v", err)
+	}
+	if resp.StatusCode != fiber.StatusServiceUnavailable {
+		t.Fatalf("GET /readyz status = %d, want %d", resp.StatusCode, fiber.StatusServiceUnavailable)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	var report HealthReport
+	if err := json.Unmarshal(body, &report); err != nil {
+		t.Fatalf("decode report: %v", err)
+	}
+	if report.Status != StatusDown || report.Checks["orders-db"].Status != StatusDown {
+		t.Errorf("report = %+v, want orders-db down", report)
+	}
+	if strings.Contains(string(body), "db.internal") || strings.Contains(string(body), "password") {
+		t.Errorf("report %s reveals the check error", body)
+	}
+}
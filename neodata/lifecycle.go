@@ -0,0 +1,219 @@
+package neodata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultComponentTimeout bounds a single component's Start or Stop call.
+	defaultComponentTimeout = 10 * time.Second
+	// defaultGracePeriod bounds the drain of in-flight work when app.grace_period is not set.
+	defaultGracePeriod = 30 * time.Second
+)
+
+// Component is a dependency whose lifecycle is managed by the App (database pools, messaging clients, ...).
+type Component interface {
+	Name() string
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+// Dependent is implemented by components that must start after other components.
+// DependsOn returns the names of those components.
+type Dependent interface {
+	DependsOn() []string
+}
+
+// lifecycle keeps the registered components and starts and stops them in dependency order.
+type lifecycle struct {
+	mu         sync.Mutex
+	components []Component
+	stopped    bool
+	timeout    time.Duration
+	logger     *zap.Logger
+
+	gracePeriod time.Duration // Time given to in-flight work before Shutdown
+}
+
+func newLifecycle(l *zap.Logger, gracePeriod time.Duration) *lifecycle {
+	if gracePeriod <= 0 {
+		gracePeriod = defaultGracePeriod
+	}
+	return &lifecycle{
+		timeout:     defaultComponentTimeout,
+		logger:      l,
+		gracePeriod: gracePeriod,
+	}
+}
+
+// register adds a component; names must be unique.
+func (lc *lifecycle) register(c Component) error {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	for _, existing := range lc.components {
+		if existing.Name() == c.Name() {
+			return fmt.Errorf("component %q already registered", c.Name())
+		}
+	}
+	lc.components = append(lc.components, c)
+	return nil
+}
+
+// get returns a registered component by name.
+func (lc *lifecycle) get(name string) (Component, bool) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	for _, c := range lc.components {
+		if c.Name() == name {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// list returns a copy of the registered components in registration order.
+func (lc *lifecycle) list() []Component {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	return append([]Component(nil), lc.components...)
+}
+
+// order sorts the components so that every component comes after its dependencies.
+// Components without dependencies keep their registration order.
+func (lc *lifecycle) order() ([]Component, error) {
+	byName := make(map[string]Component, len(lc.components))
+	for _, c := range lc.components {
+		byName[c.Name()] = c
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(lc.components))
+	ordered := make([]Component, 0, len(lc.components))
+
+	var visit func(c Component) error
+	visit = func(c Component) error {
+		switch state[c.Name()] {
+		case visiting:
+			return fmt.Errorf("dependency cycle detected at component %q", c.Name())
+		case visited:
+			return nil
+		}
+		state[c.Name()] = visiting
+		if dep, ok := c.(Dependent); ok {
+			for _, name := range dep.DependsOn() {
+				target, ok := byName[name]
+				if !ok {
+					return fmt.Errorf("component %q depends on unknown component %q", c.Name(), name)
+				}
+				if err := visit(target); err != nil {
+					return err
+				}
+			}
+		}
+		state[c.Name()] = visited
+		ordered = append(ordered, c)
+		return nil
+	}
+
+	for _, c := range lc.components {
+		if err := visit(c); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+// start starts every component in dependency order and stops at the first failure.
+// Components are created by the options, so the caller is expected to call stop
+// afterwards to release them whether start succeeded or not.
+func (lc *lifecycle) start(ctx context.Context) error {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	ordered, err := lc.order()
+	if err != nil {
+		return err
+	}
+
+	for _, c := range ordered {
+		if err := lc.call(ctx, c, c.Start); err != nil {
+			lc.logger.Error("Failed to start component", zap.String("component", c.Name()), zap.Error(err))
+			return fmt.Errorf("failed to start component %s: %w", c.Name(), err)
+		}
+		lc.logger.Info("Component started", zap.String("component", c.Name()))
+	}
+	return nil
+}
+
+// stop stops every component in reverse dependency order and returns all errors joined together.
+// Only the first call has an effect.
+func (lc *lifecycle) stop(ctx context.Context) error {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if lc.stopped {
+		return nil
+	}
+	lc.stopped = true
+
+	ordered, err := lc.order()
+	if err != nil {
+		// Fall back to registration order so that resources are still released
+		ordered = lc.components
+	}
+
+	var errs []error
+	for i := len(ordered) - 1; i >= 0; i-- {
+		c := ordered[i]
+		if err := lc.call(ctx, c, c.Stop); err != nil {
+			lc.logger.Error("Failed to stop component", zap.String("component", c.Name()), zap.Error(err))
+			errs = append(errs, fmt.Errorf("failed to stop component %s: %w", c.Name(), err))
+			continue
+		}
+		lc.logger.Info("Component stopped", zap.String("component", c.Name()))
+	}
+	return errors.Join(errs...)
+}
+
+// call runs fn with the per-component timeout, giving up when the timeout expires
+// even if fn does not honour its context.
+func (lc *lifecycle) call(ctx context.Context, c Component, fn func(context.Context) error) error {
+	callCtx, cancel := context.WithTimeout(ctx, lc.timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- fn(callCtx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-callCtx.Done():
+		return fmt.Errorf("component %s: %w", c.Name(), callCtx.Err())
+	}
+}
+
+// WithComponentTimeout sets how long a single component may take to start or stop.
+func WithComponentTimeout(timeout time.Duration) Option {
+	return func(ctx *NeoCtx) error {
+		if timeout <= 0 {
+			return fmt.Errorf("component timeout must be positive, got %s", timeout)
+		}
+		ctx.lifecycle.timeout = timeout
+		return nil
+	}
+}
@@ -0,0 +1,161 @@
+package neodata
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// recordingComponent appends its start and stop calls to a shared log.
+type recordingComponent struct {
+	name      string
+	dependsOn []string
+	calls     *[]string
+	startErr  error
+	block     chan struct{} // Stop blocks until closed when set
+}
+
+func (c *recordingComponent) Name() string        { return c.name }
+func (c *recordingComponent) DependsOn() []string { return c.dependsOn }
+
+func (c *recordingComponent) Start(context.Context) error {
+	*c.calls = append(*c.calls, "start "+c.name)
+	return c.startErr
+}
+
+func (c *recordingComponent) Stop(context.Context) error {
+	if c.block != nil {
+		<-c.block
+	}
+	*c.calls = append(*c.calls, "stop "+c.name)
+	return nil
+}
+
+func TestLifecycleOrder(t *testing.T) {
+	var calls []string
+	lc := newLifecycle(zap.NewNop(), 0)
+	for _, c := range []*recordingComponent{
+		{name: "api", dependsOn: []string{"cache", "db"}, calls: &calls},
+		{name: "db", calls: &calls},
+		{name: "cache", dependsOn: []string{"db"}, calls: &calls},
+		{name: "metrics", calls: &calls},
+	} {
+		if err := lc.register(c); err != nil {
+			t.Fatalf("register(%s) error = %v", c.name, err)
+		}
+	}
+
+	if err := lc.start(context.Background()); err != nil {
+		t.Fatalf("start() error = %v", err)
+	}
+	if err := lc.stop(context.Background()); err != nil {
+		t.Fatalf("stop() error = %v", err)
+	}
+	if err := lc.stop(context.Background()); err != nil {
+		t.Fatalf("second stop() error = %v", err)
+	}
+
+	want := []string{
+		"start db", "start cache", "start api", "start metrics",
+		"stop metrics", "stop api", "stop cache", "stop db",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestLifecycleStartFailure(t *testing.T) {
+	var calls []string
+	failure := errors.New("connection refused")
+	lc := newLifecycle(zap.NewNop(), 0)
+	_ = lc.register(&recordingComponent{name: "db", calls: &calls})
+	_ = lc.register(&recordingComponent{name: "nats", calls: &calls, startErr: failure})
+	_ = lc.register(&recordingComponent{name: "cache", calls: &calls})
+
+	if err := lc.start(context.Background()); !errors.Is(err, failure) {
+		t.Fatalf("start() error = %v, want %v", err, failure)
+	}
+	if want := []string{"start db", "start nats"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestLifecycleInvalidDependencies(t *testing.T) {
+	var calls []string
+	tests := []struct {
+		name       string
+		components []*recordingComponent
+		want       string
+	}{
+		{
+			name: "cycle",
+			components: []*recordingComponent{
+				{name: "a", dependsOn: []string{"b"}, calls: &calls},
+				{name: "b", dependsOn: []string{"a"}, calls: &calls},
+			},
+			want: "dependency cycle",
+		},
+		{
+			name:       "unknown",
+			components: []*recordingComponent{{name: "a", dependsOn: []string{"db"}, calls: &calls}},
+			want:       `unknown component "db"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc := newLifecycle(zap.NewNop(), 0)
+			for _, c := range tt.components {
+				_ = lc.register(c)
+			}
+			if err := lc.start(context.Background()); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("start() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLifecycleRejectsDuplicateNames(t *testing.T) {
+	var calls []string
+	lc := newLifecycle(zap.NewNop(), 0)
+	if err := lc.register(&recordingComponent{name: "db", calls: &calls}); err != nil {
+		t.Fatalf("register() error = %v", err)
+	}
+	if err := lc.register(&recordingComponent{name: "db", calls: &calls}); err == nil {
+		t.Error("register() of a duplicate name error = nil")
+	}
+}
+
+func TestLifecycleStopTimeout(t *testing.T) {
+	var calls []string
+	block := make(chan struct{})
+	defer close(block)
+
+	lc := newLifecycle(zap.NewNop(), 0)
+	lc.timeout = 10 * time.Millisecond
+	_ = lc.register(&recordingComponent{name: "db", calls: &calls})
+	_ = lc.register(&recordingComponent{name: "stuck", calls: &calls, block: block})
+
+	err := lc.stop(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "stuck") {
+		t.Fatalf("stop() error = %v, want the timeout of stuck", err)
+	}
+	// The components after the stuck one are still released
+	if want := []string{"stop db"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestWithComponentTimeout(t *testing.T) {
+	app := newTestApp(t, WithComponentTimeout(time.Second))
+	if app.Context.lifecycle.timeout != time.Second {
+		t.Errorf("component timeout = %s, want 1s", app.Context.lifecycle.timeout)
+	}
+	if err := WithComponentTimeout(0)(app.Context); err == nil {
+		t.Error("WithComponentTimeout(0) error = nil")
+	}
+}
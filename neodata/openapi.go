@@ -0,0 +1,578 @@
+package neodata
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/neodata-io/neodata-go/errors"
+	transport "github.com/neodata-io/neodata-go/infrastructure/transport/http"
+)
+
+// OpenAPIVersion is the version of the OpenAPI specification the generated document follows.
+const OpenAPIVersion = "3.1.0"
+
+// bearerScheme is the name of the security scheme used by authenticated routes.
+const bearerScheme = "bearerAuth"
+
+// problemSchema is the name of the component schema of problem+json responses.
+const problemSchema = "ProblemDetails"
+
+// OpenAPIConfig configures the generated document and where it is served.
+type OpenAPIConfig struct {
+	Title       string // Defaults to app.name
+	Version     string // Defaults to 1.0.0
+	Description string
+	Path        string // Path of the JSON document, defaults to /openapi.json
+	UIPath      string // Path of the documentation UI, empty disables it
+	UI          string // "swagger" (default) or "redoc"
+}
+
+// OpenAPIDocument is an OpenAPI 3.1 document.
+type OpenAPIDocument struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       OpenAPIInfo                      `json:"info"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components Components                       `json:"components"`
+}
+
+// OpenAPIInfo is the info object of the document.
+type OpenAPIInfo struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Operation documents one method of a path.
+type Operation struct {
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+	Permissions []PermissionInfo      `json:"x-permissions,omitempty"`
+}
+
+// Parameter is a path, query or header parameter.
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody is the JSON body of an operation.
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response is a documented response of an operation.
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType holds the schema of a body.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components holds the reusable schemas and security schemes.
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas,omitempty"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme describes how routes are authenticated.
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// Schema is a JSON Schema (draft 2020-12) as used by OpenAPI 3.1.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+}
+
+// openAPIMethods are the methods a path item can document; routes of other methods, such as the
+// CONNECT route of Router.Any, are left out.
+var openAPIMethods = map[string]bool{
+	http.MethodGet: true, http.MethodPut: true, http.MethodPost: true, http.MethodDelete: true,
+	http.MethodOptions: true, http.MethodHead: true, http.MethodPatch: true, http.MethodTrace: true,
+}
+
+// GenerateOpenAPI builds the OpenAPI document of the routes registered through Router.
+//
+// Security is documented from the route options only: authentication set with Authenticated or
+// Permission, on the route or with Router.With, is documented, while an auth middleware passed to
+// Router.Group or Router.Use is not.
+func (n *NeoCtx) GenerateOpenAPI(info OpenAPIInfo) *OpenAPIDocument {
+	g := &schemaGenerator{schemas: map[string]*Schema{}, names: map[reflect.Type]string{}}
+	g.component(reflect.TypeFor[transport.ProblemDetails]())
+
+	doc := &OpenAPIDocument{
+		OpenAPI: OpenAPIVersion,
+		Info:    info,
+		Paths:   map[string]map[string]*Operation{},
+		Components: Components{
+			Schemas: g.schemas,
+			SecuritySchemes: map[string]SecurityScheme{
+				bearerScheme: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+
+	for _, route := range n.Routes() {
+		if !openAPIMethods[route.Method] {
+			continue
+		}
+		path := openAPIPath(route.Path)
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = map[string]*Operation{}
+		}
+		doc.Paths[path][strings.ToLower(route.Method)] = g.operation(route)
+	}
+	return doc
+}
+
+// fiberParam matches the Fiber path params (:id, :id?, :id<int>) and wildcards (*, +).
+var fiberParam = regexp.MustCompile(`:(\w+)\??(<[^>]*>)?|[*+]`)
+
+// openAPIPath converts a Fiber path to an OpenAPI path template, e.g. /orders/:id to /orders/{id}.
+func openAPIPath(path string) string {
+	wildcards := 0
+	return fiberParam.ReplaceAllStringFunc(path, func(match string) string {
+		if match == "*" || match == "+" {
+			wildcards++
+			return "{wildcard" + strconv.Itoa(wildcards) + "}"
+		}
+		return "{" + fiberParam.FindStringSubmatch(match)[1] + "}"
+	})
+}
+
+// schemaGenerator turns Go types into schemas, registering named structs as components.
+type schemaGenerator struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+// operation documents a single route.
+func (g *schemaGenerator) operation(route RouteInfo) *Operation {
+	op := &Operation{
+		Summary:     route.Summary,
+		Description: route.Description,
+		Tags:        route.Tags,
+		Permissions: route.Permissions,
+		Responses:   map[string]*Response{},
+	}
+
+	success := &Response{Description: http.StatusText(http.StatusOK)}
+	if route.Response != nil {
+		success.Content = map[string]*MediaType{fiber.MIMEApplicationJSON: {Schema: g.schemaFor(route.Response)}}
+	}
+	op.Responses[strconv.Itoa(http.StatusOK)] = success
+
+	if route.Request != nil {
+		g.requestParts(op, route.Request)
+		g.addProblem(op, http.StatusBadRequest, "Invalid request")
+	}
+	if route.Authenticated {
+		op.Security = []map[string][]string{{bearerScheme: {}}}
+		g.addProblem(op, http.StatusUnauthorized, "Missing or invalid bearer token")
+	}
+	if len(route.Permissions) > 0 {
+		g.addProblem(op, http.StatusForbidden, "Permission denied")
+	}
+	for _, err := range route.Errors {
+		g.addProblem(op, transport.StatusCode(err), errorName(err))
+	}
+	g.addProblem(op, http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+	return op
+}
+
+// errorName returns the code of an AppError or the type name of an error, used to describe its response.
+func errorName(err error) string {
+	if appErr, ok := err.(*errors.AppError); ok {
+		return string(appErr.Code)
+	}
+	t := reflect.TypeOf(err)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+// addProblem documents a problem+json response, merging descriptions of errors sharing a status.
+func (g *schemaGenerator) addProblem(op *Operation, status int, description string) {
+	key := strconv.Itoa(status)
+	if existing, ok := op.Responses[key]; ok {
+		if !strings.Contains(existing.Description, description) {
+			existing.Description += ", " + description
+		}
+		return
+	}
+	op.Responses[key] = &Response{
+		Description: description,
+		Content: map[string]*MediaType{
+			transport.ProblemContentType: {Schema: &Schema{Ref: "#/components/schemas/" + problemSchema}},
+		},
+	}
+}
+
+// requestParts splits a request type into parameters (uri, query and header tags) and a JSON body.
+func (g *schemaGenerator) requestParts(op *Operation, t reflect.Type) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]*MediaType{fiber.MIMEApplicationJSON: {Schema: g.schemaFor(t)}},
+		}
+		return
+	}
+
+	body := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for _, field := range structFields(t) {
+		schema := g.schemaFor(field.Type)
+		rules := validationRules(field)
+		applyRules(schema, field.Type, rules)
+		_, required := rules["required"]
+
+		if in, name := paramLocation(field); in != "" {
+			op.Parameters = append(op.Parameters, &Parameter{
+				Name:     name,
+				In:       in,
+				Required: required || in == "path",
+				Schema:   schema,
+			})
+			continue
+		}
+
+		name, ok := jsonName(field)
+		if !ok {
+			continue
+		}
+		body.Properties[name] = schema
+		if required {
+			body.Required = append(body.Required, name)
+		}
+	}
+
+	if len(body.Properties) > 0 {
+		op.RequestBody = &RequestBody{
+			Required: len(body.Required) > 0,
+			Content:  map[string]*MediaType{fiber.MIMEApplicationJSON: {Schema: body}},
+		}
+	}
+}
+
+// paramLocation returns where a request field is bound from, or "" for body fields.
+func paramLocation(field reflect.StructField) (string, string) {
+	for _, loc := range []struct{ tag, in string }{{"uri", "path"}, {"query", "query"}, {"header", "header"}} {
+		if name := strings.Split(field.Tag.Get(loc.tag), ",")[0]; name != "" {
+			return loc.in, name
+		}
+	}
+	return "", ""
+}
+
+// jsonName returns the JSON name of a field, following encoding/json.
+func jsonName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	if name := strings.Split(tag, ",")[0]; name != "" {
+		return name, true
+	}
+	return field.Name, true
+}
+
+// structFields returns the exported fields of t, flattening embedded structs.
+func structFields(t reflect.Type) []reflect.StructField {
+	var fields []reflect.StructField
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				fields = append(fields, structFields(ft)...)
+				continue
+			}
+		}
+		if field.IsExported() {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// schemaFor returns the schema of t; named structs are registered as components and referenced.
+func (g *schemaGenerator) schemaFor(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		return &Schema{Ref: "#/components/schemas/" + g.component(t)}
+	}
+	return g.inlineSchema(t)
+}
+
+// component registers a named struct and returns its component name.
+func (g *schemaGenerator) component(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+
+	name := componentName(t)
+	for i := 2; g.schemas[name] != nil; i++ {
+		name = fmt.Sprintf("%s%d", componentName(t), i)
+	}
+	g.names[t] = name
+	g.schemas[name] = &Schema{} // Placeholder for recursive types
+	*g.schemas[name] = *g.inlineSchema(t)
+	return name
+}
+
+// packagePath matches the package paths generic instantiations carry in their type names.
+var packagePath = regexp.MustCompile(`[\w./-]*\.`)
+
+// componentName turns a type name into a valid component name.
+func componentName(t reflect.Type) string {
+	name := packagePath.ReplaceAllString(t.Name(), "")
+	return strings.NewReplacer("[", "_", "]", "", ",", "_", "*", "").Replace(name)
+}
+
+// inlineSchema builds the schema of t without registering it.
+func (g *schemaGenerator) inlineSchema(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaFor(t.Elem())}
+	case reflect.Struct:
+		schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+		for _, field := range structFields(t) {
+			name, ok := jsonName(field)
+			if !ok {
+				continue
+			}
+			fieldSchema := g.schemaFor(field.Type)
+			rules := validationRules(field)
+			applyRules(fieldSchema, field.Type, rules)
+			schema.Properties[name] = fieldSchema
+			if _, required := rules["required"]; required {
+				schema.Required = append(schema.Required, name)
+			}
+		}
+		sort.Strings(schema.Required)
+		return schema
+	default:
+		return &Schema{} // Any value
+	}
+}
+
+// validationRules parses the validate tag of a field into rule -> parameter.
+func validationRules(field reflect.StructField) map[string]string {
+	rules := map[string]string{}
+	for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+		if rule == "" {
+			continue
+		}
+		name, param, _ := strings.Cut(rule, "=")
+		rules[name] = param
+	}
+	return rules
+}
+
+// applyRules documents the validation rules on an inline schema. References are left untouched.
+func applyRules(schema *Schema, t reflect.Type, rules map[string]string) {
+	if schema.Ref != "" {
+		return
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	for rule, param := range rules {
+		switch rule {
+		case "email":
+			schema.Format = "email"
+		case "uuid", "uuid4":
+			schema.Format = "uuid"
+		case "url", "uri":
+			schema.Format = "uri"
+		case "oneof":
+			schema.Enum = strings.Fields(param)
+		case "min", "gte":
+			setBound(schema, t, param, true)
+		case "max", "lte":
+			setBound(schema, t, param, false)
+		case "len":
+			setBound(schema, t, param, true)
+			setBound(schema, t, param, false)
+		}
+	}
+}
+
+// setBound applies a min or max rule as a length, item count or numeric bound depending on the kind.
+func setBound(schema *Schema, t reflect.Type, param string, lower bool) {
+	value, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+	count := int(value)
+
+	switch t.Kind() {
+	case reflect.String:
+		if lower {
+			schema.MinLength = &count
+		} else {
+			schema.MaxLength = &count
+		}
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if lower {
+			schema.MinItems = &count
+		} else {
+			schema.MaxItems = &count
+		}
+	default:
+		if lower {
+			schema.Minimum = &value
+		} else {
+			schema.Maximum = &value
+		}
+	}
+}
+
+// openAPIUI are the HTML pages rendering the document, loaded from a CDN.
+var openAPIUI = map[string]string{
+	"swagger": `<!DOCTYPE html>
+<html>
+<head>
+<title>%[1]s</title>
+<link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+<script>window.ui = SwaggerUIBundle({url: "%[2]s", dom_id: "#swagger-ui"});</script>
+</body>
+</html>`,
+	"redoc": `<!DOCTYPE html>
+<html>
+<head>
+<title>%[1]s</title>
+</head>
+<body>
+<redoc spec-url="%[2]s"></redoc>
+<script src="https://cdn.redoc.ly/redoc/latest/bundles/redoc.standalone.js"></script>
+</body>
+</html>`,
+}
+
+// WithOpenAPI serves the OpenAPI document of the routes registered through Router, and optionally a
+// Swagger UI or Redoc page. The document is generated on request, so routes registered after New are included.
+func WithOpenAPI(cfg OpenAPIConfig) Option {
+	return func(ctx *NeoCtx) error {
+		httpServer, err := ctx.GetHTTPServer()
+		if err != nil {
+			return fmt.Errorf("OpenAPI requires the HTTP server: %w", err)
+		}
+
+		info := OpenAPIInfo{Title: cfg.Title, Version: cfg.Version, Description: cfg.Description}
+		if info.Title == "" {
+			info.Title = ctx.Config.App.Name
+		}
+		if info.Version == "" {
+			info.Version = "1.0.0"
+		}
+		if cfg.Path == "" {
+			cfg.Path = "/openapi.json"
+		}
+
+		// Regenerate only when routes were added since the last request
+		var (
+			mu     sync.Mutex
+			cached *OpenAPIDocument
+			count  int
+		)
+		httpServer.Get(cfg.Path, func(c fiber.Ctx) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if routes := len(ctx.Routes()); cached == nil || routes != count {
+				cached, count = ctx.GenerateOpenAPI(info), routes
+			}
+			return c.JSON(cached)
+		})
+
+		if cfg.UIPath != "" {
+			if cfg.UI == "" {
+				cfg.UI = "swagger"
+			}
+			page, ok := openAPIUI[cfg.UI]
+			if !ok {
+				return fmt.Errorf("unsupported OpenAPI UI %q", cfg.UI)
+			}
+			body := fmt.Sprintf(page, html.EscapeString(info.Title), html.EscapeString(cfg.Path))
+			httpServer.Get(cfg.UIPath, func(c fiber.Ctx) error {
+				c.Type("html")
+				return c.SendString(body)
+			})
+		}
+
+		ctx.Logger.Info("OpenAPI document initialized")
+		return nil
+	}
+}
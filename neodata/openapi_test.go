@@ -0,0 +1,35 @@
+package neodata
+
+import "testing"
+
+func TestGenerateOpenAPISkipsUndocumentedMethods(t *testing.T) {
+	app := newTestApp(t, WithHTTPServer())
+	NewRouter(app.Context).Any("/echo", func(ctx *RequestCtx) (interface{}, error) { return nil, nil })
+
+	doc := app.Context.GenerateOpenAPI(OpenAPIInfo{Title: "test", Version: "1.0.0"})
+	operations := doc.Paths["/echo"]
+	if operations["get"] == nil || operations["trace"] == nil {
+		t.Errorf("operations of /echo = %v, want every documented method", operations)
+	}
+	if _, ok := operations["connect"]; ok {
+		t.Error("CONNECT route is documented, want it left out")
+	}
+}
+
+func TestGenerateOpenAPIDocumentsRouterOptions(t *testing.T) {
+	app := newTestApp(t, WithHTTPServer())
+	orders := NewRouter(app.Context).Group("/orders").With(Authenticated())
+	orders.GET("/:id", func(ctx *RequestCtx) (interface{}, error) { return nil, nil })
+
+	doc := app.Context.GenerateOpenAPI(OpenAPIInfo{Title: "test", Version: "1.0.0"})
+	op := doc.Paths["/orders/{id}"]["get"]
+	if op == nil {
+		t.Fatalf("paths = %v, want GET /orders/{id}", doc.Paths)
+	}
+	if len(op.Security) == 0 {
+		t.Error("security of GET /orders/{id} is empty, want the bearer scheme of the group")
+	}
+	if op.Responses["401"] == nil {
+		t.Error("GET /orders/{id} does not document 401")
+	}
+}
@@ -0,0 +1,61 @@
+package neodata
+
+import (
+	"maps"
+	"time"
+
+	"github.com/neodata-io/neodata-go/config"
+	"github.com/neodata-io/neodata-go/logger"
+	"go.uber.org/zap"
+)
+
+// watchConfig applies the runtime settings of every reloaded configuration: the log levels,
+// the HTTP rate limit and the policy reload interval. Other settings need a restart.
+func (n *NeoCtx) watchConfig(manager config.Reloadable, sections []string) error {
+	manager.OnReloadError(func(err error) {
+		n.Logger.Error("Configuration reload rejected, keeping the current configuration", zap.Error(err))
+	})
+	manager.OnChange(n.applyConfigChange)
+	return manager.Watch(sections...)
+}
+
+// applyConfigChange is the OnChange subscriber of the App.
+func (n *NeoCtx) applyConfigChange(old, new *config.AppConfig) {
+	n.Logger.Info("Configuration reloaded", zap.Stringers("changes", config.Diff(old, new)))
+
+	if new.Logger.LogLevel != old.Logger.LogLevel {
+		// The level was validated with the configuration
+		if level, err := logger.ParseLevel(new.Logger.LogLevel); err == nil {
+			n.logLevels.Root().SetLevel(level)
+			n.Logger.Info("Log level changed", zap.String("level", level.String()))
+		}
+	}
+	if !maps.Equal(new.Logger.Modules, old.Logger.Modules) {
+		if err := n.logLevels.SetModuleLevels(new.Logger.Modules); err != nil {
+			n.Logger.Error("Failed to change module log levels", zap.Error(err))
+		} else {
+			n.Logger.Info("Module log levels changed", zap.Any("modules", new.Logger.Modules))
+		}
+	}
+
+	if new.App.RateLimit != old.App.RateLimit && n.rateLimiter != nil {
+		n.rateLimiter.SetLimit(new.App.RateLimit)
+		n.Logger.Info("Rate limit changed", zap.Int("requests_per_minute", new.App.RateLimit))
+	}
+
+	if interval := policyReloadInterval(new); interval != policyReloadInterval(old) {
+		component, _ := n.lifecycle.get(ComponentPolicyManager)
+		if policies, ok := component.(*policyComponent); ok {
+			policies.setReloadInterval(interval)
+			n.Logger.Info("Policy reload interval changed", zap.Duration("interval", interval))
+		}
+	}
+}
+
+// policyReloadInterval returns policy_manager.reload_interval, zero when the section is missing.
+func policyReloadInterval(cfg *config.AppConfig) time.Duration {
+	if cfg.PolicyManager == nil {
+		return 0
+	}
+	return cfg.PolicyManager.ReloadInterval * time.Second
+}
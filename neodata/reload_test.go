@@ -0,0 +1,73 @@
+package neodata
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeTestConfig(t *testing.T, path, logLevel string) {
+	t.Helper()
+	content := "app:\n  name: test\nlogger:\n  log_level: " + logLevel + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+}
+
+// waitForLogLevel polls the configuration until its log level is want or the timeout expires.
+func waitForLogLevel(app *App, want string, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if app.ConfigManager.GetAppConfig().Logger.LogLevel == want {
+			return true
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return false
+}
+
+func TestConfigWatchFollowsLifecycle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeTestConfig(t, path, "error")
+	app, err := NewWithConfig([]ConfigOption{WithConfigFile(path)})
+	if err != nil {
+		t.Fatalf("NewWithConfig() error = %v", err)
+	}
+
+	writeTestConfig(t, path, "warn")
+	if waitForLogLevel(app, "warn", 200*time.Millisecond) {
+		t.Fatal("configuration reloaded before the app started")
+	}
+
+	if err := app.Context.lifecycle.start(context.Background()); err != nil {
+		t.Fatalf("start() error = %v", err)
+	}
+	writeTestConfig(t, path, "info")
+	if !waitForLogLevel(app, "info", 5*time.Second) {
+		t.Fatal("configuration not reloaded while the app runs")
+	}
+
+	if err := app.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	writeTestConfig(t, path, "debug")
+	if waitForLogLevel(app, "debug", 200*time.Millisecond) {
+		t.Error("configuration reloaded after Shutdown")
+	}
+}
+
+func TestWithoutConfigWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeTestConfig(t, path, "error")
+	app, err := NewWithConfig([]ConfigOption{WithConfigFile(path)}, WithoutConfigWatch())
+	if err != nil {
+		t.Fatalf("NewWithConfig() error = %v", err)
+	}
+	for _, component := range app.Context.lifecycle.components {
+		if component.Name() == ComponentConfigWatcher {
+			t.Fatal("config watcher registered with WithoutConfigWatch")
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package neodata
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/neodata-io/neodata-go/domain/entities"
+	"github.com/neodata-io/neodata-go/infrastructure/transport/http"
+	"github.com/neodata-io/neodata-go/logger"
+	"go.uber.org/zap"
+)
+
+// RequestCtx gives a handler access to the app services of NeoCtx together with the current request.
+// Context and Logger shadow the app-wide fields of NeoCtx with request-scoped ones.
+type RequestCtx struct {
+	*NeoCtx
+
+	// Context is cancelled when the handler returns or the server shuts down.
+	// fasthttp does not report client disconnects while a handler is running.
+	// It carries Logger, see logger.FromContext.
+	Context       context.Context
+	Logger        *zap.Logger      // Logger carrying the correlation ID, method, path, route, user and trace IDs
+	CorrelationID string           // Set by CorrelationIDMiddleware
+	Claims        *entities.Claims // Set by AuthMiddleware, nil for unauthenticated requests
+
+	fiber fiber.Ctx
+}
+
+// newRequestCtx creates the context for a single request. The returned cancel func must be
+// called once the handler has returned.
+func newRequestCtx(ctx *NeoCtx, c fiber.Ctx) (*RequestCtx, context.CancelFunc) {
+	reqCtx, cancel := context.WithCancel(c.UserContext())
+
+	// fasthttp closes Done when the server shuts down. It is read here since the
+	// Fiber context is released once the handler returns.
+	shutdown := c.Context().Done()
+	go func() {
+		select {
+		case <-shutdown:
+			cancel()
+		case <-reqCtx.Done():
+		}
+	}()
+
+	correlationID, _ := c.Locals(http.LocalsCorrelationID).(string)
+	claims, _ := c.Locals(http.LocalsClaims).(*entities.Claims)
+
+	fields := []zap.Field{
+		zap.String("correlation_id", correlationID),
+		zap.String("method", c.Method()),
+		zap.String("path", c.Path()),
+		zap.String("route", c.Route().Path),
+	}
+	if claims != nil {
+		fields = append(fields, zap.String("user_id", claims.UserID))
+	}
+	logCtx := logger.WithContext(reqCtx, ctx.Logger.With(fields...))
+
+	return &RequestCtx{
+		NeoCtx:        ctx,
+		Context:       logCtx,
+		Logger:        logger.FromContext(logCtx),
+		CorrelationID: correlationID,
+		Claims:        claims,
+		fiber:         c,
+	}, cancel
+}
+
+// Fiber returns the underlying Fiber context.
+func (r *RequestCtx) Fiber() fiber.Ctx {
+	return r.fiber
+}
+
+// UserID returns the authenticated user ID, or an empty string for unauthenticated requests.
+func (r *RequestCtx) UserID() string {
+	if r.Claims == nil {
+		return ""
+	}
+	return r.Claims.UserID
+}
+
+// Param returns a path parameter, or defaultValue if it is empty.
+func (r *RequestCtx) Param(key string, defaultValue ...string) string {
+	return r.fiber.Params(key, defaultValue...)
+}
+
+// Query returns a query string parameter, or defaultValue if it is empty.
+func (r *RequestCtx) Query(key string, defaultValue ...string) string {
+	return r.fiber.Query(key, defaultValue...)
+}
+
+// Header returns a request header, or defaultValue if it is empty.
+func (r *RequestCtx) Header(key string, defaultValue ...string) string {
+	return r.fiber.Get(key, defaultValue...)
+}
+
+// SetHeader sets a response header.
+func (r *RequestCtx) SetHeader(key, value string) {
+	r.fiber.Set(key, value)
+}
+
+// Status sets the response status code, e.g. 201 for a created resource.
+func (r *RequestCtx) Status(code int) *RequestCtx {
+	r.fiber.Status(code)
+	return r
+}
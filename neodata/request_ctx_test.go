@@ -0,0 +1,78 @@
+package neodata
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/neodata-io/neodata-go/domain/entities"
+	"github.com/neodata-io/neodata-go/logger"
+)
+
+func TestRequestCtx(t *testing.T) {
+	app := newTestApp(t, WithHTTPServer())
+	var reqCtx *RequestCtx
+	NewRouter(app.Context).POST("/orders/:id", func(ctx *RequestCtx) (interface{}, error) {
+		reqCtx = ctx
+		ctx.Status(fiber.StatusCreated).SetHeader("Location", "/orders/"+ctx.Param("id"))
+		if ctx.Context.Err() != nil {
+			t.Error("request context cancelled while the handler runs")
+		}
+		return map[string]string{"id": ctx.Param("id"), "source": ctx.Query("source", "web"), "tenant": ctx.Header("X-Tenant")}, nil
+	})
+	srv, _ := app.Context.GetHTTPServer()
+
+	req := httptest.NewRequest("POST", "/orders/42", nil)
+	req.Header.Set("X-Correlation-ID", "req-1")
+	req.Header.Set("X-Tenant", "acme")
+	resp, err := srv.Test(req)
+	if err != nil {
+		t.Fatalf("Test() error = %v", err)
+	}
+	if resp.StatusCode != fiber.StatusCreated || resp.Header.Get("Location") != "/orders/42" {
+		t.Errorf("response = %d %q, want 201 /orders/42", resp.StatusCode, resp.Header.Get("Location"))
+	}
+	if body, _ := io.ReadAll(resp.Body); !strings.Contains(string(body), `"source":"web"`) || !strings.Contains(string(body), `"tenant":"acme"`) {
+		t.Errorf("body = %s, want the default query value and the header", body)
+	}
+
+	if reqCtx.NeoCtx != app.Context {
+		t.Error("request context does not share the services of the app")
+	}
+	if reqCtx.CorrelationID != "req-1" || reqCtx.UserID() != "" || reqCtx.Claims != nil {
+		t.Errorf("request context = %q %q %v, want correlation ID req-1 and no user", reqCtx.CorrelationID, reqCtx.UserID(), reqCtx.Claims)
+	}
+	if reqCtx.Logger == app.Logger || logger.FromContext(reqCtx.Context) != reqCtx.Logger {
+		t.Error("request logger is not the request-scoped logger carried by the context")
+	}
+	if reqCtx.Context.Err() == nil {
+		t.Error("request context not cancelled once the handler returned")
+	}
+}
+
+func TestRequestCtxClaims(t *testing.T) {
+	app := newTestApp(t, WithHTTPServer())
+	var userID string
+	NewRouter(app.Context).GET("/me", func(ctx *RequestCtx) (interface{}, error) {
+		userID = ctx.UserID()
+		return nil, nil
+	}, Authenticated())
+	srv, _ := app.Context.GetHTTPServer()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, entities.Claims{UserID: "u-1"}).SignedString([]byte(app.Config.Auth.JwtSecret))
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+	req := httptest.NewRequest("GET", "/me", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := srv.Test(req)
+	if err != nil {
+		t.Fatalf("Test() error = %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK || userID != "u-1" {
+		t.Errorf("GET /me = %d with user %q, want 200 with u-1", resp.StatusCode, userID)
+	}
+}
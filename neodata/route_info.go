@@ -0,0 +1,100 @@
+package neodata
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+)
+
+// RouteInfo describes a route registered through Router. It is the source of the OpenAPI document.
+type RouteInfo struct {
+	Method        string
+	Path          string       // Full Fiber path including the group prefixes
+	Request       reflect.Type // Bound request type, nil for untyped handlers
+	Response      reflect.Type // Response type, nil for untyped handlers
+	Summary       string
+	Description   string
+	Tags          []string
+	Authenticated bool
+	Permissions   []PermissionInfo
+	Errors        []error // Errors the route may return, e.g. errors.ErrNotFound
+}
+
+// PermissionInfo is a permission required by a route.
+type PermissionInfo struct {
+	Resource string `json:"resource"`
+	Action   string `json:"action"`
+}
+
+// routeRegistry keeps the metadata of the registered routes in registration order, and the
+// errors of the routes that could not be registered.
+type routeRegistry struct {
+	mu     sync.RWMutex
+	routes []RouteInfo
+	errs   []error
+}
+
+func (rr *routeRegistry) add(info RouteInfo) {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+	rr.routes = append(rr.routes, info)
+}
+
+func (rr *routeRegistry) list() []RouteInfo {
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
+	return append([]RouteInfo(nil), rr.routes...)
+}
+
+func (rr *routeRegistry) fail(err error) {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+	rr.errs = append(rr.errs, err)
+}
+
+func (rr *routeRegistry) err() error {
+	rr.mu.RLock()
+	defer rr.mu.RUnlock()
+	return errors.Join(rr.errs...)
+}
+
+// RouteErrors returns the errors of the routes that could not be registered, e.g. a route with
+// Permission without WithPolicyManager, joined together. App.Run fails with them.
+func (n *NeoCtx) RouteErrors() error {
+	return n.routes.err()
+}
+
+// Routes returns the metadata of every route registered through Router.
+func (n *NeoCtx) Routes() []RouteInfo {
+	return n.routes.list()
+}
+
+// Summary sets the short description of the route in the API documentation.
+func Summary(summary string) RouteOption {
+	return func(cfg *routeConfig) {
+		cfg.summary = summary
+	}
+}
+
+// Description sets the long description of the route in the API documentation.
+func Description(description string) RouteOption {
+	return func(cfg *routeConfig) {
+		cfg.description = description
+	}
+}
+
+// Tags groups the route under the given tags in the API documentation.
+func Tags(tags ...string) RouteOption {
+	return func(cfg *routeConfig) {
+		cfg.tags = append(cfg.tags, tags...)
+	}
+}
+
+// Errors documents the errors the route may return, using the types of the errors package:
+//
+//	router.GET("/orders/:id", getOrder, neodata.Errors(errors.ErrNotFound))
+func Errors(errs ...error) RouteOption {
+	return func(cfg *routeConfig) {
+		cfg.errors = append(cfg.errors, errs...)
+	}
+}
@@ -1,21 +1,29 @@
 package neodata
 
 import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
 	"github.com/gofiber/fiber/v3"
-	"go.uber.org/zap"
 )
 
 // Router provides methods for registering HTTP routes
 type Router struct {
-	server *fiber.App
+	server fiber.Router
 	ctx    *NeoCtx
+	prefix string        // Path prefix of the group, empty for the root router
+	opts   []RouteOption // Options applied to every route of the router, see With
 }
 
-// NewRouter initializes a new Router instance
+// NewRouter initializes a new Router instance. Without an HTTP server, the routes registered on
+// the router are dropped and App.Run fails, see NeoCtx.RouteErrors.
 func NewRouter(ctx *NeoCtx) *Router {
 	httpServer, err := ctx.GetHTTPServer()
 	if err != nil {
-		ctx.Logger.Fatal("Err", zap.Error(err))
+		ctx.routes.fail(fmt.Errorf("router requires the HTTP server: %w", err))
+		return &Router{ctx: ctx}
 	}
 
 	return &Router{
@@ -24,22 +32,199 @@ func NewRouter(ctx *NeoCtx) *Router {
 	}
 }
 
+// HandlerFunc handles a request and returns the value sent back as JSON
+type HandlerFunc func(*RequestCtx) (interface{}, error)
+
+// RouteOption configures a single route
+type RouteOption func(*routeConfig)
+
+// routeConfig collects the options of a route
+type routeConfig struct {
+	middlewares   []routeMiddleware
+	authenticated bool
+
+	// Documentation only, see openapi.go
+	summary     string
+	description string
+	tags        []string
+	errors      []error
+	permissions []PermissionInfo
+}
+
+// routeMiddleware builds a route middleware once the app services are known
+type routeMiddleware func(*NeoCtx) (fiber.Handler, error)
+
+// Middleware adds middlewares that run, in order, before the route handler
+// (authentication, rate limiting, policy checks, ...).
+func Middleware(handlers ...fiber.Handler) RouteOption {
+	return func(cfg *routeConfig) {
+		for _, handler := range handlers {
+			cfg.middlewares = append(cfg.middlewares, func(*NeoCtx) (fiber.Handler, error) {
+				return handler, nil
+			})
+		}
+	}
+}
+
+// newRouteConfig applies the route options
+func newRouteConfig(opts []RouteOption) *routeConfig {
+	cfg := &routeConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// handlers builds the route middlewares in the order the options declared them
+func (cfg *routeConfig) handlers(ctx *NeoCtx) ([]fiber.Handler, error) {
+	handlers := make([]fiber.Handler, 0, len(cfg.middlewares))
+	for _, build := range cfg.middlewares {
+		handler, err := build(ctx)
+		if err != nil {
+			return nil, err
+		}
+		handlers = append(handlers, handler)
+	}
+	return handlers, nil
+}
+
+// Group returns a sub-router whose routes share the prefix and run the given middlewares first.
+// The group keeps the options of the router, see With.
+//
+//	v1 := router.Group("/api/v1", http.AuthMiddleware(secret))
+//	admin := v1.Group("/admin", http.RateLimiterMiddleware(10, time.Minute))
+func (r *Router) Group(prefix string, middlewares ...fiber.Handler) *Router {
+	if r.server == nil {
+		return &Router{ctx: r.ctx, prefix: r.prefix + prefix, opts: r.opts}
+	}
+	return &Router{
+		server: r.server.Group(prefix, middlewares...),
+		ctx:    r.ctx,
+		prefix: r.prefix + prefix,
+		opts:   r.opts,
+	}
+}
+
+// With returns a router applying the given options to every route it registers, before the
+// options of the route. Unlike group middlewares, the options are part of the route metadata, so
+// authentication set here is documented by the OpenAPI document:
+//
+//	orders := router.Group("/orders").With(neodata.Authenticated(), neodata.Tags("orders"))
+func (r *Router) With(opts ...RouteOption) *Router {
+	return &Router{
+		server: r.server,
+		ctx:    r.ctx,
+		prefix: r.prefix,
+		opts:   append(append([]RouteOption(nil), r.opts...), opts...),
+	}
+}
+
+// Use adds middlewares that run for every route of the router registered afterwards
+func (r *Router) Use(middlewares ...fiber.Handler) *Router {
+	if r.server == nil {
+		return r
+	}
+	for _, middleware := range middlewares {
+		r.server.Use(middleware)
+	}
+	return r
+}
+
+// Handle registers a route for an arbitrary HTTP method. The method must be one of the request
+// methods of the HTTP server, see fiber.Config.RequestMethods, or App.Run fails.
+func (r *Router) Handle(method, path string, handler HandlerFunc, opts ...RouteOption) {
+	r.add([]string{method}, path, wrapHandler(r.ctx, handler), nil, nil, opts)
+}
+
 // GET registers a GET route with a custom handler function
-func (r *Router) GET(path string, handler func(*NeoCtx) (interface{}, error)) {
-	r.server.Get(path, wrapHandler(r.ctx, handler))
+func (r *Router) GET(path string, handler HandlerFunc, opts ...RouteOption) {
+	r.Handle(fiber.MethodGet, path, handler, opts...)
 }
 
 // POST registers a POST route with a custom handler function
-func (r *Router) POST(path string, handler func(*NeoCtx) (interface{}, error)) {
-	r.server.Post(path, wrapHandler(r.ctx, handler))
+func (r *Router) POST(path string, handler HandlerFunc, opts ...RouteOption) {
+	r.Handle(fiber.MethodPost, path, handler, opts...)
+}
+
+// PUT registers a PUT route with a custom handler function
+func (r *Router) PUT(path string, handler HandlerFunc, opts ...RouteOption) {
+	r.Handle(fiber.MethodPut, path, handler, opts...)
+}
+
+// PATCH registers a PATCH route with a custom handler function
+func (r *Router) PATCH(path string, handler HandlerFunc, opts ...RouteOption) {
+	r.Handle(fiber.MethodPatch, path, handler, opts...)
+}
+
+// DELETE registers a DELETE route with a custom handler function
+func (r *Router) DELETE(path string, handler HandlerFunc, opts ...RouteOption) {
+	r.Handle(fiber.MethodDelete, path, handler, opts...)
+}
+
+// HEAD registers a HEAD route with a custom handler function
+func (r *Router) HEAD(path string, handler HandlerFunc, opts ...RouteOption) {
+	r.Handle(fiber.MethodHead, path, handler, opts...)
+}
+
+// OPTIONS registers an OPTIONS route with a custom handler function
+func (r *Router) OPTIONS(path string, handler HandlerFunc, opts ...RouteOption) {
+	r.Handle(fiber.MethodOptions, path, handler, opts...)
+}
+
+// Any registers a route matching every HTTP method
+func (r *Router) Any(path string, handler HandlerFunc, opts ...RouteOption) {
+	if r.server == nil {
+		return // Reported by NewRouter
+	}
+	r.add(r.ctx.httpServer.Config().RequestMethods, path, wrapHandler(r.ctx, handler), nil, nil, opts)
+}
+
+// add registers a Fiber handler behind the route middlewares and records the route metadata.
+// reqType and respType are nil for untyped handlers.
+func (r *Router) add(methods []string, path string, handler fiber.Handler, reqType, respType reflect.Type, opts []RouteOption) {
+	if r.server == nil {
+		return // Reported by NewRouter
+	}
+	for _, method := range methods {
+		// Fiber panics on methods it does not serve
+		if !slices.Contains(r.ctx.httpServer.Config().RequestMethods, strings.ToUpper(method)) {
+			r.ctx.routes.fail(fmt.Errorf("failed to configure route %s %s: unsupported HTTP method", method, r.prefix+path))
+			return
+		}
+	}
+	cfg := newRouteConfig(append(append([]RouteOption(nil), r.opts...), opts...))
+	middlewares, err := cfg.handlers(r.ctx)
+	if err != nil {
+		r.ctx.routes.fail(fmt.Errorf("failed to configure route %s %s: %w", strings.Join(methods, ","), r.prefix+path, err))
+		return
+	}
+	r.server.Add(methods, path, handler, middlewares...)
+
+	for _, method := range methods {
+		r.ctx.routes.add(RouteInfo{
+			Method:        strings.ToUpper(method),
+			Path:          r.prefix + path,
+			Request:       reqType,
+			Response:      respType,
+			Summary:       cfg.summary,
+			Description:   cfg.description,
+			Tags:          cfg.tags,
+			Authenticated: cfg.authenticated,
+			Permissions:   cfg.permissions,
+			Errors:        cfg.errors,
+		})
+	}
 }
 
 // wrapHandler wraps a handler to match Fiber's handler signature
-func wrapHandler(ctx *NeoCtx, handler func(*NeoCtx) (interface{}, error)) fiber.Handler {
+func wrapHandler(ctx *NeoCtx, handler HandlerFunc) fiber.Handler {
 	return func(c fiber.Ctx) error {
-		result, err := handler(ctx)
+		reqCtx, cancel := newRequestCtx(ctx, c)
+		defer cancel()
+
+		result, err := handler(reqCtx)
 		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+			return translateError(err) // Rendered by the server's error handler
 		}
 		return c.JSON(result)
 	}
@@ -0,0 +1,124 @@
+package neodata
+
+import (
+	"context"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+func TestRouteOptionErrorsFailRun(t *testing.T) {
+	app := newTestApp(t, WithHTTPServer())
+	router := NewRouter(app.Context)
+	router.GET("/orders/:id", func(ctx *RequestCtx) (interface{}, error) { return nil, nil }, Permission("orders:{id}", "read"))
+	router.GET("/health", func(ctx *RequestCtx) (interface{}, error) { return nil, nil })
+
+	err := app.Context.RouteErrors()
+	if err == nil || !strings.Contains(err.Error(), "GET /orders/:id") || !strings.Contains(err.Error(), "policy manager") {
+		t.Fatalf("RouteErrors() = %v, want the policy manager error of GET /orders/:id", err)
+	}
+	if routes := app.Context.Routes(); len(routes) != 1 || routes[0].Path != "/health" {
+		t.Errorf("Routes() = %+v, want only GET /health", routes)
+	}
+
+	if err := app.RunContext(context.Background()); err == nil || !strings.Contains(err.Error(), "invalid routes") {
+		t.Errorf("RunContext() = %v, want the route errors", err)
+	}
+}
+
+func TestRouterWithoutHTTPServer(t *testing.T) {
+	app := newTestApp(t)
+	router := NewRouter(app.Context).Group("/api")
+	router.GET("/orders", func(ctx *RequestCtx) (interface{}, error) { return nil, nil })
+	router.Any("/echo", func(ctx *RequestCtx) (interface{}, error) { return nil, nil })
+
+	if err := app.Context.RouteErrors(); err == nil || !strings.Contains(err.Error(), "HTTP server") {
+		t.Errorf("RouteErrors() = %v, want the missing HTTP server", err)
+	}
+	if routes := app.Context.Routes(); len(routes) != 0 {
+		t.Errorf("Routes() = %+v, want none", routes)
+	}
+}
+
+func TestRouterVerbs(t *testing.T) {
+	app := newTestApp(t, WithHTTPServer())
+	router := NewRouter(app.Context)
+	handler := func(ctx *RequestCtx) (interface{}, error) { return ctx.Fiber().Method(), nil }
+	router.GET("/orders", handler)
+	router.POST("/orders", handler)
+	router.PUT("/orders/:id", handler)
+	router.PATCH("/orders/:id", handler)
+	router.DELETE("/orders/:id", handler)
+	router.HEAD("/orders", handler)
+	router.OPTIONS("/orders", handler)
+	router.Handle("trace", "/orders", handler)
+	router.Any("/echo", handler)
+	srv, _ := app.Context.GetHTTPServer()
+
+	for _, tt := range []struct{ method, path string }{
+		{"GET", "/orders"}, {"POST", "/orders"}, {"PUT", "/orders/1"}, {"PATCH", "/orders/1"}, {"DELETE", "/orders/1"},
+		{"HEAD", "/orders"}, {"OPTIONS", "/orders"}, {"TRACE", "/orders"}, {"GET", "/echo"}, {"DELETE", "/echo"},
+	} {
+		resp, err := srv.Test(httptest.NewRequest(tt.method, tt.path, nil))
+		if err != nil {
+			t.Fatalf("Test() error = %v", err)
+		}
+		if resp.StatusCode != fiber.StatusOK {
+			t.Errorf("%s %s status = %d, want %d", tt.method, tt.path, resp.StatusCode, fiber.StatusOK)
+		}
+	}
+
+	if err := app.Context.RouteErrors(); err != nil {
+		t.Errorf("RouteErrors() = %v", err)
+	}
+	if resp, _ := srv.Test(httptest.NewRequest("DELETE", "/orders", nil)); resp.StatusCode != fiber.StatusMethodNotAllowed {
+		t.Errorf("DELETE /orders status = %d, want %d", resp.StatusCode, fiber.StatusMethodNotAllowed)
+	}
+}
+
+func TestRouterGroupsAndMiddlewares(t *testing.T) {
+	app := newTestApp(t, WithHTTPServer())
+	var order []string
+	trace := func(name string) fiber.Handler {
+		return func(c fiber.Ctx) error {
+			order = append(order, name)
+			return c.Next()
+		}
+	}
+
+	v1 := NewRouter(app.Context).Group("/api/v1", trace("group")).With(Tags("orders"))
+	v1.Use(trace("use"))
+	v1.Group("/orders").GET("/:id", func(ctx *RequestCtx) (interface{}, error) {
+		order = append(order, "handler")
+		return ctx.Param("id"), nil
+	}, Middleware(trace("route")))
+	srv, _ := app.Context.GetHTTPServer()
+
+	resp, err := srv.Test(httptest.NewRequest("GET", "/api/v1/orders/42", nil))
+	if err != nil {
+		t.Fatalf("Test() error = %v", err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, fiber.StatusOK)
+	}
+	if want := []string{"group", "use", "route", "handler"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("middlewares ran in order %v, want %v", order, want)
+	}
+
+	routes := app.Context.Routes()
+	if len(routes) != 1 || routes[0].Path != "/api/v1/orders/:id" || !reflect.DeepEqual(routes[0].Tags, []string{"orders"}) {
+		t.Errorf("Routes() = %+v, want GET /api/v1/orders/:id tagged orders", routes)
+	}
+}
+
+func TestRouterUnsupportedMethod(t *testing.T) {
+	app := newTestApp(t, WithHTTPServer())
+	NewRouter(app.Context).Handle("PURGE", "/cache", func(ctx *RequestCtx) (interface{}, error) { return nil, nil })
+
+	if err := app.Context.RouteErrors(); err == nil || !strings.Contains(err.Error(), "PURGE /cache") {
+		t.Errorf("RouteErrors() = %v, want the unsupported method of PURGE /cache", err)
+	}
+}
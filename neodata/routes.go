@@ -1,38 +1,26 @@
 package neodata
 
 import (
-	"github.com/gofiber/fiber/v3"
+	"fmt"
+	"slices"
+	"strings"
 
 	"go.uber.org/zap"
 )
 
 // RegisterRoute allows microservices to add custom routes with a simple syntax.
-func RegisterRoute(ctx *NeoCtx, method, path string, handler func(*NeoCtx) (interface{}, error)) {
+func RegisterRoute(ctx *NeoCtx, method, path string, handler HandlerFunc, opts ...RouteOption) {
 	httpServer, err := ctx.GetHTTPServer()
 	if err != nil {
-		ctx.Logger.Fatal("Err", zap.Error(err))
+		ctx.routes.fail(fmt.Errorf("route %s %s requires the HTTP server: %w", method, path, err))
+		return
 	}
 
-	// Wrap handler function to match Fiber's handler signature
-	fiberHandler := func(c fiber.Ctx) error {
-		result, err := handler(ctx)
-		if err != nil {
-			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
-		}
-		return c.JSON(result)
-	}
-
-	// Register the route based on the method
-	switch method {
-	case "GET":
-		httpServer.Get(path, fiberHandler)
-	case "POST":
-		httpServer.Post(path, fiberHandler)
-	case "PUT":
-		httpServer.Put(path, fiberHandler)
-	case "DELETE":
-		httpServer.Delete(path, fiberHandler)
-	default:
+	// Register the route for any method the server accepts
+	method = strings.ToUpper(method)
+	if !slices.Contains(httpServer.Config().RequestMethods, method) {
 		ctx.Logger.Warn("Unsupported method", zap.String("method", method))
+		return
 	}
+	(&Router{server: httpServer, ctx: ctx}).Handle(method, path, handler, opts...)
 }